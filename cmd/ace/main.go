@@ -17,7 +17,11 @@ limitations under the License.
 package main
 
 import (
+	"os"
+
 	"go.bytebuilders.dev/cli/pkg/cmds"
+	"go.bytebuilders.dev/cli/pkg/exitcode"
+	"go.bytebuilders.dev/cli/pkg/printer"
 	_ "go.bytebuilders.dev/license-verifier/info"
 
 	"gomodules.xyz/logs"
@@ -26,7 +30,9 @@ import (
 
 func main() {
 	if err := realMain(); err != nil {
-		klog.Fatalln(err)
+		printer.PrintError(err)
+		klog.Flush()
+		os.Exit(exitcode.Code(err))
 	}
 }
 
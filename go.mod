@@ -7,6 +7,8 @@ require (
 	github.com/envoyproxy/gateway v1.6.3
 	github.com/fatih/color v1.18.0
 	github.com/fluxcd/helm-controller/api v1.2.0
+	github.com/fluxcd/pkg/apis/meta v1.10.0
+	github.com/mattn/go-isatty v0.0.20
 	github.com/minio/minio-go/v7 v7.0.97
 	github.com/nats-io/nats.go v1.49.0
 	github.com/pkg/errors v0.9.1
@@ -17,6 +19,8 @@ require (
 	go.bytebuilders.dev/license-verifier v0.15.0
 	go.bytebuilders.dev/resource-model v0.3.0
 	gocloud.dev v0.41.0
+	golang.org/x/sync v0.19.0
+	golang.org/x/term v0.38.0
 	gomodules.xyz/blobfs v0.2.2
 	gomodules.xyz/go-sh v0.2.0
 	gomodules.xyz/logs v0.0.7
@@ -109,7 +113,6 @@ require (
 	github.com/fatih/structs v1.1.0 // indirect
 	github.com/felixge/httpsnoop v1.0.4 // indirect
 	github.com/fluxcd/pkg/apis/kustomize v1.9.0 // indirect
-	github.com/fluxcd/pkg/apis/meta v1.10.0 // indirect
 	github.com/fsnotify/fsnotify v1.9.0 // indirect
 	github.com/fxamacker/cbor/v2 v2.9.0 // indirect
 	github.com/go-errors/errors v1.5.1 // indirect
@@ -158,7 +161,6 @@ require (
 	github.com/kylelemons/godebug v1.1.0 // indirect
 	github.com/liggitt/tabwriter v0.0.0-20181228230101-89fcab3d43de // indirect
 	github.com/mattn/go-colorable v0.1.14 // indirect
-	github.com/mattn/go-isatty v0.0.20 // indirect
 	github.com/minio/crc64nvme v1.1.0 // indirect
 	github.com/minio/md5-simd v1.1.2 // indirect
 	github.com/mitchellh/copystructure v1.2.0 // indirect
@@ -219,9 +221,7 @@ require (
 	golang.org/x/crypto v0.46.0 // indirect
 	golang.org/x/net v0.47.0 // indirect
 	golang.org/x/oauth2 v0.33.0 // indirect
-	golang.org/x/sync v0.19.0 // indirect
 	golang.org/x/sys v0.40.0 // indirect
-	golang.org/x/term v0.38.0 // indirect
 	golang.org/x/text v0.32.0 // indirect
 	golang.org/x/time v0.14.0 // indirect
 	golang.org/x/xerrors v0.0.0-20240903120638-7835f813f4da // indirect
@@ -0,0 +1,197 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Community License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Community-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package clustercache keeps the clusters listed from the platform in a local cache per context,
+// so that shell completion and `ace cluster list --cached` do not need to round-trip to the API.
+package clustercache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go.bytebuilders.dev/cli/pkg/config"
+	"go.bytebuilders.dev/resource-model/apis/cluster/v1alpha1"
+)
+
+// DefaultTTL is the age after which the cached clusters are considered stale.
+const DefaultTTL = 5 * time.Minute
+
+// orgSeparator separates the context and the organization in the name of the cache files
+const orgSeparator = "@"
+
+var ErrNotCached = errors.New("no cached clusters found for the current context")
+
+// entry is the content of a cache file.
+type entry struct {
+	FetchedAt time.Time                 `json:"fetchedAt"`
+	Clusters  *v1alpha1.ClusterInfoList `json:"clusters"`
+	// Partial is set if the clusters were listed without their status
+	Partial bool `json:"partial,omitempty"`
+}
+
+// Dir returns the directory that holds the cache files.
+func Dir() (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "cache", "clusters"), nil
+}
+
+// path returns the cache file of the current context. Organizations selected with --org are
+// cached separately from the default organization of the context. The organization is escaped,
+// so that it can't point outside the cache directory.
+func path() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	name, err := contextName()
+	if err != nil {
+		return "", err
+	}
+	if config.Organization != "" {
+		name += orgSeparator + url.PathEscape(config.Organization)
+	}
+	return filepath.Join(dir, name+".json"), nil
+}
+
+func contextName() (string, error) {
+	name, err := config.GetCurrentContextName()
+	if err != nil {
+		return "", err
+	}
+	if name == "" {
+		return "", fmt.Errorf("no current context is set")
+	}
+	return filepath.Base(name), nil
+}
+
+// Organizations returns the organizations selected with --org whose clusters are cached for the
+// current context.
+func Organizations() ([]string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	name, err := contextName()
+	if err != nil {
+		return nil, err
+	}
+	files, err := filepath.Glob(filepath.Join(dir, name+orgSeparator+"*.json"))
+	if err != nil {
+		return nil, err
+	}
+	orgs := make([]string, 0, len(files))
+	for _, file := range files {
+		org, err := url.PathUnescape(strings.TrimSuffix(strings.TrimPrefix(filepath.Base(file), name+orgSeparator), ".json"))
+		if err != nil {
+			continue
+		}
+		orgs = append(orgs, org)
+	}
+	return orgs, nil
+}
+
+// Load returns the cached clusters of the current context along with the time they were fetched.
+// The clusters cached without their status by SavePartial are not returned.
+func Load() (*v1alpha1.ClusterInfoList, time.Time, error) {
+	return load(false)
+}
+
+// LoadPartial is like Load, but it also returns the clusters cached without their status. It is
+// meant for shell completion, which only needs the names of the clusters.
+func LoadPartial() (*v1alpha1.ClusterInfoList, time.Time, error) {
+	return load(true)
+}
+
+func load(partial bool) (*v1alpha1.ClusterInfoList, time.Time, error) {
+	p, err := path()
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	data, err := os.ReadFile(p)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, time.Time{}, ErrNotCached
+	}
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil || e.Clusters == nil || (e.Partial && !partial) {
+		return nil, time.Time{}, ErrNotCached
+	}
+	return e.Clusters, e.FetchedAt, nil
+}
+
+// Save replaces the cached clusters of the current context.
+func Save(clusters *v1alpha1.ClusterInfoList) error {
+	return save(clusters, false)
+}
+
+// SavePartial replaces the cached clusters of the current context with clusters listed without
+// their status. They are only used by LoadPartial.
+func SavePartial(clusters *v1alpha1.ClusterInfoList) error {
+	return save(clusters, true)
+}
+
+func save(clusters *v1alpha1.ClusterInfoList, partial bool) error {
+	p, err := path()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(entry{FetchedAt: time.Now().UTC(), Clusters: clusters, Partial: partial})
+	if err != nil {
+		return err
+	}
+	// write to a temporary file first, so that concurrent readers never see a partial file
+	tmp, err := os.CreateTemp(filepath.Dir(p), filepath.Base(p)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // nolint:errcheck
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), p)
+}
+
+// Invalidate removes the cached clusters of the current context. It is called after the commands
+// that add, remove or change clusters.
+func Invalidate() error {
+	p, err := path()
+	if err != nil {
+		return err
+	}
+	err = os.Remove(p)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,139 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Community License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Community-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"fmt"
+	"os"
+
+	"go.bytebuilders.dev/cli/pkg/completion"
+	"go.bytebuilders.dev/cli/pkg/config"
+	"go.bytebuilders.dev/cli/pkg/exitcode"
+	"go.bytebuilders.dev/cli/pkg/printer"
+
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+)
+
+// fleetFlags holds the flags shared by apply and diff.
+type fleetFlags struct {
+	file           string
+	prune          bool
+	catalogCluster string
+	concurrency    int
+}
+
+func (ff *fleetFlags) addFlags(f *config.Factory, cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&ff.file, "filename", "f", "", "Path of the fleet file that declares the desired clusters")
+	cmd.Flags().BoolVar(&ff.prune, "prune", false, "Remove the clusters that are not declared in the fleet file")
+	cmd.Flags().StringVar(&ff.catalogCluster, "catalog-cluster", "", "Name of the cluster to validate the cluster profiles and feature sets of new clusters against (default is the first active cluster)")
+	_ = cmd.RegisterFlagCompletionFunc("catalog-cluster", completion.ClusterNames(f))
+	cmd.Flags().IntVar(&ff.concurrency, "concurrency", defaultBulkConcurrency, "Maximum number of clusters processed in parallel")
+	_ = cmd.MarkFlagRequired("filename")
+}
+
+func (ff *fleetFlags) plan(f *config.Factory) (*fleetPlan, error) {
+	if ff.concurrency < 1 {
+		return nil, exitcode.Validationf("--concurrency must be at least 1")
+	}
+	spec, err := loadFleetSpec(ff.file)
+	if err != nil {
+		return nil, err
+	}
+	return planFleet(f, spec, ff.prune, ff.catalogCluster, ff.concurrency)
+}
+
+func NewCmdDiff(f *config.Factory) *cobra.Command {
+	ff := fleetFlags{}
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Show the changes 'ace apply' would make to bring the clusters to the state of a fleet file",
+		Long: `Show the changes 'ace apply' would make to bring the clusters to the state of a fleet file.
+
+The command exits with a non-zero code if the changes of any cluster can't be planned.`,
+		DisableAutoGenTag: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			plan, err := ff.plan(f)
+			if err != nil {
+				return err
+			}
+			if err := printer.PrintFleetPlan(plan.changes); err != nil {
+				return err
+			}
+			return plan.planError()
+		},
+	}
+	ff.addFlags(f, cmd)
+	return cmd
+}
+
+func NewCmdApply(f *config.Factory) *cobra.Command {
+	ff := fleetFlags{}
+	jf := jobFlags{}
+	var yes bool
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Import, reconfigure and prune clusters to match a fleet file",
+		Long: `Compare the clusters of the organization with the fleet file, then import the missing clusters
+and reconfigure the clusters whose feature sets have drifted. The clusters that are not declared
+in the fleet file are only removed with --prune. Run 'ace diff' to review the changes first.
+
+` + bulkExitCodeUsage,
+		DisableAutoGenTag: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			plan, err := ff.plan(f)
+			if err != nil {
+				return err
+			}
+			if printer.OutputFormat != "json" {
+				if err := printer.PrintFleetPlan(plan.changes); err != nil {
+					return err
+				}
+			}
+
+			var removed []string
+			for _, c := range plan.changes {
+				if c.Action == printer.FleetActionRemove {
+					removed = append(removed, c.Cluster)
+				}
+			}
+			if len(removed) > 0 && !yes {
+				if !isatty.IsTerminal(os.Stdin.Fd()) {
+					return fmt.Errorf("refusing to prune %d clusters without confirmation. Use --yes to skip the confirmation", len(removed))
+				}
+				printer.Progressf("\n")
+				if err := askForConfirmation(); err != nil {
+					return err
+				}
+			}
+
+			if len(plan.pending()) > 0 {
+				if printer.OutputFormat != "json" {
+					fmt.Println()
+				}
+				if err := applyFleetPlan(f, plan, ff.concurrency, jf); err != nil {
+					return err
+				}
+			}
+			return plan.planError()
+		},
+	}
+	ff.addFlags(f, cmd)
+	jf.addFlags(cmd)
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Prune the clusters without asking for confirmation")
+	return cmd
+}
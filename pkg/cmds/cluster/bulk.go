@@ -0,0 +1,203 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Community License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Community-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"os/signal"
+	"slices"
+	"strings"
+
+	"go.bytebuilders.dev/cli/pkg/completion"
+	"go.bytebuilders.dev/cli/pkg/config"
+	"go.bytebuilders.dev/cli/pkg/exitcode"
+	"go.bytebuilders.dev/cli/pkg/printer"
+	ace "go.bytebuilders.dev/client"
+	clustermodel "go.bytebuilders.dev/resource-model/apis/cluster"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const defaultBulkConcurrency = 5
+
+// bulkExitCodeUsage documents the exit code of the commands that run a job for multiple clusters.
+var bulkExitCodeUsage = fmt.Sprintf(`When multiple clusters are targeted, the command exits with code %d if it was terminated
+by the user before all the jobs finished, with code %d if the job failed for any of the clusters
+and with code %d otherwise.`, exitcode.Cancelled, exitcode.RemoteFailure, exitcode.Success)
+
+// clusterTargets holds the flags used to select the clusters a command operates on.
+type clusterTargets struct {
+	names       []string
+	selector    string
+	all         bool
+	concurrency int
+}
+
+func (t *clusterTargets) addFlags(f *config.Factory, cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&t.names, "name", nil, "Name of the cluster. Can be repeated to target multiple clusters")
+	cmd.Flags().StringVarP(&t.selector, "selector", "l", "", "Label selector to filter the clusters. The 'provider' and 'phase' of the cluster can also be used as labels (e.g. provider=EKS,phase=Active)")
+	cmd.Flags().BoolVar(&t.all, "all", false, "Target all the clusters of the organization")
+	cmd.Flags().IntVar(&t.concurrency, "concurrency", defaultBulkConcurrency, "Maximum number of clusters processed in parallel when multiple clusters are targeted")
+	cmd.MarkFlagsMutuallyExclusive("name", "selector", "all")
+	_ = cmd.RegisterFlagCompletionFunc("name", completion.ClusterNames(f))
+}
+
+// isBulk reports whether the command may target more than one cluster.
+func (t *clusterTargets) isBulk() bool {
+	return t.all || t.selector != "" || len(t.names) > 1
+}
+
+// resolve returns the names of the targeted clusters.
+func (t *clusterTargets) resolve(f *config.Factory) ([]string, error) {
+	if t.concurrency < 1 {
+		return nil, exitcode.Validationf("--concurrency must be at least 1")
+	}
+	if len(t.names) > 0 {
+		names := slices.Clone(t.names)
+		slices.Sort(names)
+		return slices.Compact(names), nil
+	}
+	if !t.all && t.selector == "" {
+		return nil, exitcode.Validationf("please provide the clusters using --name, --selector or --all")
+	}
+
+	selector, err := labels.Parse(t.selector)
+	if err != nil {
+		return nil, exitcode.Validationf("invalid selector %q. Reason: %w", t.selector, err)
+	}
+	clusters, err := listClusters(f, clustermodel.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list clusters. Reason: %w", err)
+	}
+	var names []string
+	for i := range clusters.Items {
+		set := labels.Set{}
+		for k, v := range clusters.Items[i].Labels {
+			set[k] = v
+		}
+		set["provider"] = string(clusters.Items[i].Spec.Provider)
+		set["phase"] = string(clusters.Items[i].Status.Phase)
+		if selector.Matches(set) {
+			names = append(names, clusters.Items[i].Spec.Name)
+		}
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no cluster matched the provided selector")
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+type bulkJobFunc func(c *ace.Client, nc *natsConn, cluster, prefix string) error
+
+// runBulkJob runs the job for every cluster with bounded concurrency. The job steps are prefixed
+// with the cluster name, and a summary of the results is printed once all the jobs have finished.
+// Once the user terminates the command, the jobs that haven't started yet are skipped.
+func runBulkJob(f *config.Factory, action string, clusters []string, concurrency int, job bulkJobFunc) error {
+	c, err := f.Client()
+	if err != nil {
+		return err
+	}
+	nc, err := newNATSConn(c)
+	if err != nil {
+		return err
+	}
+	defer nc.Close() // nolint:errcheck
+
+	width := 0
+	for _, name := range clusters {
+		width = max(width, len(name))
+	}
+
+	// the running jobs are notified by their own canceller, this one stops the queued jobs
+	done := f.Canceller()
+	defer signal.Stop(done)
+	cancelled := make(chan struct{})
+	finished := make(chan struct{})
+	defer close(finished)
+	go func() {
+		select {
+		case <-done:
+			close(cancelled)
+		case <-finished:
+		}
+	}()
+
+	results := make([]printer.JobResult, len(clusters))
+	var g errgroup.Group
+	g.SetLimit(concurrency)
+	for i, name := range clusters {
+		g.Go(func() error {
+			select {
+			case <-cancelled:
+				results[i] = printer.JobResult{Cluster: name, Err: exitcode.ErrCancelled}
+				return nil
+			default:
+			}
+			prefix := fmt.Sprintf("[%-*s] ", width, name)
+			printer.Progressf("%sStarting %s......\n", prefix, action)
+			results[i] = printer.JobResult{Cluster: name, Err: job(c, nc, name, prefix)}
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	if printer.OutputFormat == "json" {
+		// print the result of the clusters whose job hasn't reported one, e.g. because it has failed
+		// to start or has been skipped
+		for _, r := range results {
+			var reported *reportedError
+			if r.Err == nil || errors.As(r.Err, &reported) {
+				continue
+			}
+			status := printer.JobStatusError
+			if exitcode.Code(r.Err) == exitcode.Cancelled {
+				status = printer.JobStatusCancelled
+			}
+			_ = printer.PrintJobEvent(printer.JobEvent{
+				Type:    printer.JobEventResult,
+				Cluster: r.Cluster,
+				Status:  status,
+				Message: r.Err.Error(),
+			})
+		}
+	} else {
+		fmt.Println()
+		if err := printer.PrintJobResults(results); err != nil {
+			return err
+		}
+	}
+
+	var failed []string
+	code := exitcode.RemoteFailure
+	for _, r := range results {
+		if r.Err != nil {
+			failed = append(failed, r.Cluster)
+			// a user interrupt takes precedence, any other failure is reported as a remote failure
+			if exitcode.Code(r.Err) == exitcode.Cancelled {
+				code = exitcode.Cancelled
+			}
+		}
+	}
+	if len(failed) > 0 {
+		return exitcode.New(code, fmt.Errorf("%s failed for %d of %d clusters: %s", action, len(failed), len(clusters), strings.Join(failed, ", ")))
+	}
+	return nil
+}
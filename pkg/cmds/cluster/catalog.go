@@ -0,0 +1,212 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Community License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Community-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"go.bytebuilders.dev/cli/pkg/config"
+	"go.bytebuilders.dev/cli/pkg/exitcode"
+	clustermodel "go.bytebuilders.dev/resource-model/apis/cluster"
+
+	flux "github.com/fluxcd/helm-controller/api/v2"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/klog/v2"
+	rsapi "kmodules.xyz/resource-metadata/apis/meta/v1alpha1"
+	uiapi "kmodules.xyz/resource-metadata/apis/ui/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var clusterScheme = runtime.NewScheme()
+
+var errNoCatalogCluster = errors.New("no active cluster found to read the catalog from. Please provide --catalog-cluster")
+
+func init() {
+	utilruntime.Must(uiapi.AddToScheme(clusterScheme))
+	utilruntime.Must(flux.AddToScheme(clusterScheme))
+}
+
+// newCatalogClient returns a client for the given cluster that can read the cluster profiles,
+// feature sets and HelmReleases. If no cluster name is provided, the first active cluster of the
+// organization is used.
+func newCatalogClient(f *config.Factory, clusterName string) (client.Client, error) {
+	c, err := f.Client()
+	if err != nil {
+		return nil, err
+	}
+
+	if clusterName == "" {
+		clusters, err := listClusters(f, clustermodel.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for i := range clusters.Items {
+			if clusters.Items[i].Status.Phase == rsapi.ClusterPhaseActive {
+				clusterName = clusters.Items[i].Spec.Name
+				break
+			}
+		}
+		if clusterName == "" {
+			return nil, errNoCatalogCluster
+		}
+	}
+
+	cc, err := c.GetClusterClientConfig(clustermodel.GetOptions{Name: clusterName})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client config for cluster %s. Reason: %w", clusterName, err)
+	}
+	restConfig, err := cc.ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	return client.New(restConfig, client.Options{Scheme: clusterScheme})
+}
+
+func listClusterProfiles(kc client.Client) ([]uiapi.ClusterProfile, error) {
+	var profiles uiapi.ClusterProfileList
+	err := kc.List(context.TODO(), &profiles)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cluster profiles. Reason: %w", err)
+	}
+	return profiles.Items, nil
+}
+
+// validateComponents validates the cluster profile and the feature sets against the catalog.
+// The validation is skipped if there is no cluster available to read the catalog from.
+func validateComponents(f *config.Factory, catalogCluster string, profile string, featureSets []clustermodel.FeatureSet) error {
+	return newCatalogSource(f, catalogCluster).validate(profile, featureSets)
+}
+
+// catalogSource reads the cluster profiles and feature sets from a catalog cluster. The client,
+// profiles and feature sets are resolved once and shared by the validations of multiple clusters.
+type catalogSource struct {
+	f       *config.Factory
+	cluster string
+
+	clientOnce   sync.Once
+	kc           client.Client
+	clientErr    error
+	profilesOnce sync.Once
+	profiles     []uiapi.ClusterProfile
+	profilesErr  error
+	catalogOnce  sync.Once
+	catalog      *featureCatalog
+	catalogErr   error
+	skipOnce     sync.Once
+}
+
+func newCatalogSource(f *config.Factory, catalogCluster string) *catalogSource {
+	return &catalogSource{f: f, cluster: catalogCluster}
+}
+
+func (s *catalogSource) client() (client.Client, error) {
+	s.clientOnce.Do(func() {
+		s.kc, s.clientErr = newCatalogClient(s.f, s.cluster)
+	})
+	return s.kc, s.clientErr
+}
+
+func (s *catalogSource) clusterProfiles() ([]uiapi.ClusterProfile, error) {
+	s.profilesOnce.Do(func() {
+		kc, err := s.client()
+		if err != nil {
+			s.profilesErr = err
+			return
+		}
+		s.profiles, s.profilesErr = listClusterProfiles(kc)
+	})
+	return s.profiles, s.profilesErr
+}
+
+func (s *catalogSource) featureCatalog() (*featureCatalog, error) {
+	s.catalogOnce.Do(func() {
+		kc, err := s.client()
+		if err != nil {
+			s.catalogErr = err
+			return
+		}
+		s.catalog, s.catalogErr = loadFeatureCatalog(kc)
+	})
+	return s.catalog, s.catalogErr
+}
+
+func (s *catalogSource) validate(profile string, featureSets []clustermodel.FeatureSet) error {
+	if profile == "" && len(featureSets) == 0 {
+		return nil
+	}
+	_, err := s.client()
+	if errors.Is(err, errNoCatalogCluster) {
+		s.skipOnce.Do(func() {
+			klog.Warningf("skipping cluster profile and feature set validation. Reason: %v", err)
+		})
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if profile != "" {
+		profiles, err := s.clusterProfiles()
+		if err != nil {
+			return err
+		}
+		if err := checkClusterProfile(profiles, profile); err != nil {
+			return err
+		}
+	}
+	if len(featureSets) == 0 {
+		return nil
+	}
+	catalog, err := s.featureCatalog()
+	if err != nil {
+		return err
+	}
+	return catalog.validate(featureSets)
+}
+
+func validateClusterProfile(kc client.Client, profile string) error {
+	if profile == "" {
+		return nil
+	}
+	profiles, err := listClusterProfiles(kc)
+	if err != nil {
+		return err
+	}
+	return checkClusterProfile(profiles, profile)
+}
+
+func checkClusterProfile(profiles []uiapi.ClusterProfile, profile string) error {
+	names := make([]string, 0, len(profiles))
+	for i := range profiles {
+		if profiles[i].Name == profile {
+			return nil
+		}
+		names = append(names, profiles[i].Name)
+	}
+	return fmt.Errorf("unknown cluster profile %q. Valid values are: %v", profile, names)
+}
+
+func validateEksAuthMode(mode clustermodel.EksAuthMode) error {
+	switch mode {
+	case "", clustermodel.EksAuthModeIRSA, clustermodel.EksAuthModePodIdentity:
+		return nil
+	}
+	return exitcode.Validationf("invalid EKS auth mode %q. Valid values are: %s, %s", mode, clustermodel.EksAuthModeIRSA, clustermodel.EksAuthModePodIdentity)
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"os"
 
+	"go.bytebuilders.dev/cli/pkg/completion"
 	"go.bytebuilders.dev/cli/pkg/config"
 	"go.bytebuilders.dev/cli/pkg/printer"
 	clustermodel "go.bytebuilders.dev/resource-model/apis/cluster"
@@ -37,6 +38,9 @@ func newCmdCheck(f *config.Factory) *cobra.Command {
 		Short:             "Check whether a cluster has been imported already or not",
 		DisableAutoGenTag: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateEksAuthMode(opts.Provider.EksAuthMode); err != nil {
+				return err
+			}
 			if kubeConfigPath != "" {
 				data, err := os.ReadFile(kubeConfigPath)
 				if err != nil {
@@ -52,15 +56,21 @@ func newCmdCheck(f *config.Factory) *cobra.Command {
 				fmt.Println("Cluster hasn't been imported yet.")
 				return nil
 			}
-			return printer.PrintCluster(cluster)
+			if err := printer.PrintCluster(cluster); err != nil {
+				return err
+			}
+			printer.PrintClusterHints(cluster)
+			return nil
 		},
 	}
 	cmd.Flags().StringVar(&opts.Provider.Name, "provider", "", "Name of the cluster provider")
+	_ = cmd.RegisterFlagCompletionFunc("provider", completion.ProviderNames)
 	cmd.Flags().StringVar(&opts.Provider.Credential, "credential", "", "Name of the credential with access to the provider APIs")
 	cmd.Flags().StringVar(&opts.Provider.ClusterID, "id", "", "Provider specific cluster ID")
 	cmd.Flags().StringVar(&opts.Provider.Project, "project", "", "Project where the cluster belong (use for GKE)")
 	cmd.Flags().StringVar(&opts.Provider.Region, "region", "", "Region or location of the cluster")
 	cmd.Flags().StringVar(&opts.Provider.ResourceGroup, "resource-group", "", "Resource group of the cluster (use for AKS)")
+	cmd.Flags().StringVar((*string)(&opts.Provider.EksAuthMode), "eks-auth-mode", "", "Authentication mode for the workloads of the cluster (any of IRSA,PodIdentity) (use for EKS)")
 	cmd.Flags().StringVar(&kubeConfigPath, "kubeconfig", "", "Path of the kubeconfig file")
 	return cmd
 }
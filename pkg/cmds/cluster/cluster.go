@@ -18,7 +18,6 @@ package cluster
 
 import (
 	"go.bytebuilders.dev/cli/pkg/config"
-	"go.bytebuilders.dev/cli/pkg/printer"
 	clustermodel "go.bytebuilders.dev/resource-model/apis/cluster"
 
 	"github.com/spf13/cobra"
@@ -34,11 +33,14 @@ func NewCmdCluster(f *config.Factory) *cobra.Command {
 	cmd.AddCommand(newCmdCheck(f))
 	cmd.AddCommand(newCmdImport(f))
 	cmd.AddCommand(newCmdGet(f))
+	cmd.AddCommand(newCmdDescribe(f))
+	cmd.AddCommand(newCmdInventory(f))
 	cmd.AddCommand(newCmdConnect(f))
 	cmd.AddCommand(newCmdReconfigure(f))
 	cmd.AddCommand(newCmdRemove(f))
-
-	cmd.PersistentFlags().StringVarP(&printer.OutputFormat, "output", "o", "", "Output format (any of json,yaml,table). Default is table.")
+	cmd.AddCommand(newCmdWait(f))
+	cmd.AddCommand(newCmdProfiles(f))
+	cmd.AddCommand(newCmdFeatures(f))
 	return cmd
 }
 
@@ -21,7 +21,11 @@ import (
 	"fmt"
 	"os"
 
+	"go.bytebuilders.dev/cli/pkg/cmds/utils"
+	"go.bytebuilders.dev/cli/pkg/completion"
 	"go.bytebuilders.dev/cli/pkg/config"
+	"go.bytebuilders.dev/cli/pkg/exitcode"
+	"go.bytebuilders.dev/cli/pkg/printer"
 	ace "go.bytebuilders.dev/client"
 	clustermodel "go.bytebuilders.dev/resource-model/apis/cluster"
 	"go.bytebuilders.dev/resource-model/apis/cluster/v1alpha1"
@@ -32,33 +36,44 @@ import (
 func newCmdConnect(f *config.Factory) *cobra.Command {
 	opts := clustermodel.ConnectOptions{}
 	var kubeConfigPath string
+	var portableCredentials bool
 	cmd := &cobra.Command{
 		Use:               "connect",
 		Short:             "Connect with a cluster imported by peers",
 		DisableAutoGenTag: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if portableCredentials && kubeConfigPath == "" {
+				return exitcode.Validationf("--portable-credentials requires --kubeconfig")
+			}
 			if kubeConfigPath != "" {
 				data, err := os.ReadFile(kubeConfigPath)
 				if err != nil {
 					return fmt.Errorf("failed to read Kubeconfig file. Reason: %w", err)
 				}
+				if portableCredentials {
+					data, err = utils.GeneratePortableKubeConfig(data)
+					if err != nil {
+						return fmt.Errorf("failed to generate portable kubeconfig. Reason: %w", err)
+					}
+				}
 				opts.KubeConfig = string(data)
 			}
 			_, err := connectCluster(f, opts)
 			if err != nil {
 				if errors.Is(err, ace.ErrNotFound) {
-					fmt.Println("Provided cluster does not exist. Please provide a valid cluster name.")
-					return nil
+					return &exitcode.NotFoundError{Resource: "cluster", Name: opts.Name}
 				}
 				return fmt.Errorf("failed to connect with cluster. Reason: %w", err)
 			}
-			fmt.Println("Successfully connected to the cluster")
+			printer.Progressf("Successfully connected to the cluster\n")
 			return nil
 		},
 	}
 	cmd.Flags().StringVar(&opts.Name, "name", "", "Name of the cluster to get")
+	_ = cmd.RegisterFlagCompletionFunc("name", completion.ClusterNames(f))
 	cmd.Flags().StringVar(&opts.Credential, "credential", "", "Name of the credential to use to connect with the cluster")
 	cmd.Flags().StringVar(&kubeConfigPath, "kubeconfig", "", "Path of the kubeconfig file")
+	cmd.Flags().BoolVar(&portableCredentials, "portable-credentials", false, "Use the kubeconfig credentials to create a dedicated ServiceAccount in the cluster and connect using its token (use for exec plugin based kubeconfigs)")
 	return cmd
 }
 
@@ -0,0 +1,77 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Community License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Community-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"errors"
+	"fmt"
+
+	"go.bytebuilders.dev/cli/pkg/completion"
+	"go.bytebuilders.dev/cli/pkg/config"
+	"go.bytebuilders.dev/cli/pkg/exitcode"
+	"go.bytebuilders.dev/cli/pkg/journal"
+	"go.bytebuilders.dev/cli/pkg/printer"
+	ace "go.bytebuilders.dev/client"
+	clustermodel "go.bytebuilders.dev/resource-model/apis/cluster"
+
+	"github.com/spf13/cobra"
+	"k8s.io/klog/v2"
+)
+
+// maxDescribedJobs is the number of recent jobs shown by the describe command
+const maxDescribedJobs = 5
+
+func newCmdDescribe(f *config.Factory) *cobra.Command {
+	opts := clustermodel.GetOptions{}
+	cmd := &cobra.Command{
+		Use:               "describe",
+		Short:             "Show the details and the status diagnostics of a cluster",
+		DisableAutoGenTag: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cluster, err := getCluster(f, opts)
+			if err != nil {
+				if errors.Is(err, ace.ErrNotFound) {
+					return &exitcode.NotFoundError{Resource: "cluster", Name: opts.Name}
+				}
+				return fmt.Errorf("failed to get the cluster information. Reason: %w", err)
+			}
+			return printer.PrintClusterDescription(cluster, recentJobs(opts.Name))
+		},
+	}
+	cmd.Flags().StringVar(&opts.Name, "name", "", "Name of the cluster to describe")
+	_ = cmd.RegisterFlagCompletionFunc("name", completion.ClusterNames(f))
+	return cmd
+}
+
+// recentJobs returns the latest jobs of the cluster recorded in the local journal.
+func recentJobs(cluster string) []journal.Job {
+	jobs, err := journal.List()
+	if err != nil {
+		klog.Warningf("failed to read the local job journal. Reason: %v", err)
+		return nil
+	}
+	var result []journal.Job
+	for _, job := range jobs {
+		if job.Cluster == cluster {
+			result = append(result, job)
+			if len(result) == maxDescribedJobs {
+				break
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,321 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Community License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Community-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.bytebuilders.dev/cli/pkg/completion"
+	"go.bytebuilders.dev/cli/pkg/config"
+	"go.bytebuilders.dev/cli/pkg/exitcode"
+	"go.bytebuilders.dev/cli/pkg/printer"
+	ace "go.bytebuilders.dev/client"
+	clustermodel "go.bytebuilders.dev/resource-model/apis/cluster"
+
+	flux "github.com/fluxcd/helm-controller/api/v2"
+	fluxmeta "github.com/fluxcd/pkg/apis/meta"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	uiapi "kmodules.xyz/resource-metadata/apis/ui/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newCmdFeatures(f *config.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "features",
+		Short:             "Manage feature sets installed in the clusters",
+		DisableAutoGenTag: true,
+	}
+	cmd.AddCommand(newCmdFeaturesList(f))
+	cmd.AddCommand(newCmdFeaturesStatus(f))
+	return cmd
+}
+
+func newCmdFeaturesList(f *config.Factory) *cobra.Command {
+	var catalogCluster string
+	cmd := &cobra.Command{
+		Use:               "list",
+		Short:             "List the valid feature sets and their features",
+		DisableAutoGenTag: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			kc, err := newCatalogClient(f, catalogCluster)
+			if err != nil {
+				return err
+			}
+			catalog, err := loadFeatureCatalog(kc)
+			if err != nil {
+				return err
+			}
+			if len(catalog.featureSets) == 0 && printer.IsTableOutput() {
+				fmt.Println("No feature set found.")
+				return nil
+			}
+			return printer.PrintFeatureSets(catalog.featureSetInfos())
+		},
+	}
+	cmd.Flags().StringVar(&catalogCluster, "catalog-cluster", "", "Name of the cluster to read the feature sets from (default is the first active cluster)")
+	_ = cmd.RegisterFlagCompletionFunc("catalog-cluster", completion.ClusterNames(f))
+	return cmd
+}
+
+func newCmdFeaturesStatus(f *config.Factory) *cobra.Command {
+	var name string
+	cmd := &cobra.Command{
+		Use:               "status",
+		Short:             "Show the status of the features installed in a cluster",
+		DisableAutoGenTag: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if name == "" {
+				return exitcode.Validationf("please provide the cluster name using --name")
+			}
+			kc, err := newCatalogClient(f, name)
+			if err != nil {
+				if errors.Is(err, ace.ErrNotFound) {
+					return &exitcode.NotFoundError{Resource: "cluster", Name: name}
+				}
+				return err
+			}
+			catalog, err := loadFeatureCatalog(kc)
+			if err != nil {
+				return err
+			}
+			var releases flux.HelmReleaseList
+			err = kc.List(context.TODO(), &releases)
+			if err != nil {
+				return fmt.Errorf("failed to list HelmReleases. Reason: %w", err)
+			}
+			statuses := catalog.featureStatuses(releases.Items)
+			if len(statuses) == 0 && printer.IsTableOutput() {
+				fmt.Println("No feature is installed in the cluster.")
+				return nil
+			}
+			return printer.PrintFeatureStatuses(statuses)
+		},
+	}
+	cmd.Flags().StringVar(&name, "name", "", "Name of the cluster")
+	_ = cmd.RegisterFlagCompletionFunc("name", completion.ClusterNames(f))
+	return cmd
+}
+
+// featureCatalog holds the feature sets and the features that belong to them.
+type featureCatalog struct {
+	featureSets []uiapi.FeatureSet
+	features    map[string][]uiapi.Feature
+}
+
+func loadFeatureCatalog(kc client.Client) (*featureCatalog, error) {
+	var featureSets uiapi.FeatureSetList
+	err := kc.List(context.TODO(), &featureSets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list feature sets. Reason: %w", err)
+	}
+	var features uiapi.FeatureList
+	err = kc.List(context.TODO(), &features)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list features. Reason: %w", err)
+	}
+
+	catalog := &featureCatalog{
+		featureSets: featureSets.Items,
+		features:    make(map[string][]uiapi.Feature),
+	}
+	sort.Slice(catalog.featureSets, func(i, j int) bool {
+		return catalog.featureSets[i].Name < catalog.featureSets[j].Name
+	})
+	for _, feature := range features.Items {
+		catalog.features[feature.Spec.FeatureSet] = append(catalog.features[feature.Spec.FeatureSet], feature)
+	}
+	for name := range catalog.features {
+		sort.Slice(catalog.features[name], func(i, j int) bool {
+			return catalog.features[name][i].Name < catalog.features[name][j].Name
+		})
+	}
+	return catalog, nil
+}
+
+func (c *featureCatalog) featureSetInfos() []printer.FeatureSetInfo {
+	infos := make([]printer.FeatureSetInfo, 0, len(c.featureSets))
+	for _, fs := range c.featureSets {
+		info := printer.FeatureSetInfo{
+			Name:             fs.Name,
+			Title:            fs.Spec.Title,
+			RequiredFeatures: fs.Spec.RequiredFeatures,
+		}
+		for _, feature := range c.features[fs.Name] {
+			info.Features = append(info.Features, feature.Name)
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+func (c *featureCatalog) validate(featureSets []clustermodel.FeatureSet) error {
+	for _, fs := range featureSets {
+		known := sets.New[string]()
+		for _, feature := range c.features[fs.Name] {
+			known.Insert(feature.Name)
+		}
+		if known.Len() == 0 {
+			return fmt.Errorf("unknown feature set %q. See 'ace cluster features list' for the valid feature sets", fs.Name)
+		}
+		for _, feature := range fs.Features {
+			if !known.Has(feature) {
+				return fmt.Errorf("feature %q does not belong to feature set %q. Valid features are: %s", feature, fs.Name, strings.Join(sets.List(known), ","))
+			}
+		}
+	}
+	return nil
+}
+
+// enabledFeatureSets returns the feature sets with the features that are currently enabled in the cluster.
+func (c *featureCatalog) enabledFeatureSets() []clustermodel.FeatureSet {
+	var featureSets []clustermodel.FeatureSet
+	for _, fs := range c.featureSets {
+		var enabled []string
+		for _, feature := range c.features[fs.Name] {
+			if feature.Status.Enabled != nil && *feature.Status.Enabled {
+				enabled = append(enabled, feature.Name)
+			}
+		}
+		if len(enabled) > 0 {
+			featureSets = append(featureSets, clustermodel.FeatureSet{Name: fs.Name, Features: enabled})
+		}
+	}
+	return featureSets
+}
+
+// applyFeatureSetChanges adds the features provided via --featureset to the current feature sets.
+// Features prefixed with "-" are removed instead.
+func applyFeatureSetChanges(current []clustermodel.FeatureSet, changes map[string]string) []clustermodel.FeatureSet {
+	desired := make(map[string]sets.Set[string])
+	for _, fs := range current {
+		desired[fs.Name] = sets.New(fs.Features...)
+	}
+	for name, val := range changes {
+		if _, ok := desired[name]; !ok {
+			desired[name] = sets.New[string]()
+		}
+		for _, feature := range strings.Split(val, ",") {
+			if removed, ok := strings.CutPrefix(feature, "-"); ok {
+				desired[name].Delete(removed)
+			} else {
+				desired[name].Insert(feature)
+			}
+		}
+	}
+
+	featureSets := make([]clustermodel.FeatureSet, 0, len(desired))
+	for _, name := range sets.List(sets.KeySet(desired)) {
+		if desired[name].Len() == 0 {
+			continue
+		}
+		featureSets = append(featureSets, clustermodel.FeatureSet{
+			Name:     name,
+			Features: sets.List(desired[name]),
+		})
+	}
+	return featureSets
+}
+
+// featureStatuses returns the status of the features that have a HelmRelease or are
+// reported as enabled in the cluster.
+func (c *featureCatalog) featureStatuses(releases []flux.HelmRelease) []printer.FeatureStatus {
+	// releases are matched by the namespace their chart is installed into, as releases of the same
+	// name may exist in multiple namespaces
+	releaseOf := make(map[string]*flux.HelmRelease, len(releases))
+	releaseByName := make(map[string]*flux.HelmRelease, len(releases))
+	for i := range releases {
+		releaseOf[helmReleaseTargetNamespace(&releases[i])+"/"+releases[i].Name] = &releases[i]
+		releaseByName[releases[i].Name] = &releases[i]
+	}
+
+	var statuses []printer.FeatureStatus
+	for _, fs := range c.featureSets {
+		for _, feature := range c.features[fs.Name] {
+			status := printer.FeatureStatus{
+				FeatureSet: fs.Name,
+				Feature:    feature.Name,
+			}
+			hr, found := releaseOf[feature.Spec.Chart.Namespace+"/"+feature.Name]
+			if feature.Spec.Chart.Namespace == "" {
+				hr, found = releaseByName[feature.Name]
+			}
+			switch {
+			case found:
+				status.Namespace = hr.Namespace
+				status.ChartVersion = helmReleaseChartVersion(hr)
+				status.Ready = string(metav1.ConditionUnknown)
+				if cond := meta.FindStatusCondition(hr.Status.Conditions, fluxmeta.ReadyCondition); cond != nil {
+					status.Ready = string(cond.Status)
+				}
+				status.Message = helmReleaseLastFailure(hr)
+			case feature.Status.Enabled != nil && *feature.Status.Enabled:
+				status.Ready = string(metav1.ConditionUnknown)
+				if feature.Status.Ready != nil {
+					status.Ready = string(metav1.ConditionFalse)
+					if *feature.Status.Ready {
+						status.Ready = string(metav1.ConditionTrue)
+					}
+				}
+				status.Message = feature.Status.Note
+			default:
+				continue
+			}
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses
+}
+
+func helmReleaseTargetNamespace(hr *flux.HelmRelease) string {
+	if hr.Spec.TargetNamespace != "" {
+		return hr.Spec.TargetNamespace
+	}
+	return hr.Namespace
+}
+
+// helmReleaseLastFailure returns the message of the most recent failed condition of the release,
+// even if the release has recovered since.
+func helmReleaseLastFailure(hr *flux.HelmRelease) string {
+	var last *metav1.Condition
+	for i, cond := range hr.Status.Conditions {
+		failed := cond.Status == metav1.ConditionFalse || cond.Type == flux.RemediatedCondition
+		if failed && (last == nil || cond.LastTransitionTime.After(last.LastTransitionTime.Time)) {
+			last = &hr.Status.Conditions[i]
+		}
+	}
+	if last == nil {
+		return ""
+	}
+	return last.Message
+}
+
+func helmReleaseChartVersion(hr *flux.HelmRelease) string {
+	if latest := hr.Status.History.Latest(); latest != nil {
+		return latest.ChartVersion
+	}
+	if hr.Spec.Chart != nil {
+		return hr.Spec.Chart.Spec.Version
+	}
+	return ""
+}
@@ -0,0 +1,436 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Community License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Community-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"go.bytebuilders.dev/cli/pkg/cmds/utils"
+	"go.bytebuilders.dev/cli/pkg/config"
+	"go.bytebuilders.dev/cli/pkg/exitcode"
+	"go.bytebuilders.dev/cli/pkg/printer"
+	ace "go.bytebuilders.dev/client"
+	clustermodel "go.bytebuilders.dev/resource-model/apis/cluster"
+
+	"golang.org/x/sync/errgroup"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/yaml"
+)
+
+// fleetSpec is the desired state of the clusters of the organization, as declared in a fleet file:
+//
+//	clusters:
+//	- name: prod-eks
+//	  displayName: Production
+//	  provider: EKS
+//	  credential: aws
+//	  id: prod
+//	  region: us-east-1
+//	  clusterProfile: default
+//	  featureSets:
+//	    opscenter-core: [kube-ui-server]
+type fleetSpec struct {
+	Clusters []fleetCluster `json:"clusters"`
+
+	// dir is the directory of the fleet file. The kubeconfig paths are relative to it.
+	dir string
+}
+
+// fleetCluster mirrors the flags of 'ace cluster import'.
+type fleetCluster struct {
+	Name                string                   `json:"name"`
+	DisplayName         string                   `json:"displayName,omitempty"`
+	Provider            string                   `json:"provider"`
+	Credential          string                   `json:"credential,omitempty"`
+	ID                  string                   `json:"id,omitempty"`
+	Project             string                   `json:"project,omitempty"`
+	Region              string                   `json:"region,omitempty"`
+	ResourceGroup       string                   `json:"resourceGroup,omitempty"`
+	EksAuthMode         clustermodel.EksAuthMode `json:"eksAuthMode,omitempty"`
+	KubeConfig          string                   `json:"kubeconfig,omitempty"`
+	PortableCredentials bool                     `json:"portableCredentials,omitempty"`
+	InstallFluxCD       *bool                    `json:"installFluxCD,omitempty"`
+	ClusterProfile      string                   `json:"clusterProfile,omitempty"`
+	SpokeComponents     bool                     `json:"spokeComponents,omitempty"`
+	// FeatureSets are the features per feature set. The feature sets of existing clusters are only
+	// reconciled if they are declared.
+	FeatureSets map[string][]string `json:"featureSets,omitempty"`
+}
+
+func loadFleetSpec(path string) (*fleetSpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fleet file. Reason: %w", err)
+	}
+	var spec fleetSpec
+	if err := yaml.UnmarshalStrict(data, &spec); err != nil {
+		return nil, exitcode.Validationf("failed to parse fleet file %s. Reason: %w", path, err)
+	}
+	spec.dir = filepath.Dir(path)
+
+	seen := sets.New[string]()
+	for _, c := range spec.Clusters {
+		if c.Name == "" {
+			return nil, exitcode.Validationf("every cluster of the fleet file must have a name")
+		}
+		if seen.Has(c.Name) {
+			return nil, exitcode.Validationf("cluster %s is declared more than once in the fleet file", c.Name)
+		}
+		seen.Insert(c.Name)
+		if c.Provider == "" {
+			return nil, exitcode.Validationf("provider of cluster %s is missing in the fleet file", c.Name)
+		}
+		if c.PortableCredentials && c.KubeConfig == "" {
+			return nil, exitcode.Validationf("portableCredentials of cluster %s requires kubeconfig", c.Name)
+		}
+		if err := validateEksAuthMode(c.EksAuthMode); err != nil {
+			return nil, exitcode.Validationf("invalid cluster %s. Reason: %w", c.Name, err)
+		}
+	}
+	return &spec, nil
+}
+
+func (c *fleetCluster) featureSets() []clustermodel.FeatureSet {
+	featureSets := make([]clustermodel.FeatureSet, 0, len(c.FeatureSets))
+	for name, features := range c.FeatureSets {
+		featureSets = append(featureSets, clustermodel.FeatureSet{Name: name, Features: sets.List(sets.New(features...))})
+	}
+	sort.Slice(featureSets, func(i, j int) bool {
+		return featureSets[i].Name < featureSets[j].Name
+	})
+	return featureSets
+}
+
+func (c *fleetCluster) components() clustermodel.ComponentOptions {
+	components := clustermodel.ComponentOptions{
+		FluxCD:          c.InstallFluxCD == nil || *c.InstallFluxCD,
+		ClusterProfile:  c.ClusterProfile,
+		SpokeComponents: c.SpokeComponents,
+		FeatureSets:     c.featureSets(),
+	}
+	if len(components.FeatureSets) == 0 {
+		components.FeatureSets = defaultFeatureSet
+	}
+	return components
+}
+
+func (c *fleetCluster) importOptions(dir string) (clustermodel.ImportOptions, error) {
+	opts := clustermodel.ImportOptions{
+		BasicInfo: clustermodel.BasicInfo{
+			Name:        c.Name,
+			DisplayName: c.DisplayName,
+		},
+		Provider: clustermodel.ProviderOptions{
+			Name:          c.Provider,
+			Credential:    c.Credential,
+			EksAuthMode:   c.EksAuthMode,
+			ClusterID:     c.ID,
+			Project:       c.Project,
+			Region:        c.Region,
+			ResourceGroup: c.ResourceGroup,
+		},
+		Components: c.components(),
+	}
+	if c.KubeConfig != "" {
+		path := c.KubeConfig
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return opts, fmt.Errorf("failed to read Kubeconfig file. Reason: %w", err)
+		}
+		if c.PortableCredentials {
+			data, err = utils.GeneratePortableKubeConfig(data)
+			if err != nil {
+				return opts, fmt.Errorf("failed to generate portable kubeconfig. Reason: %w", err)
+			}
+		}
+		opts.Provider.KubeConfig = string(data)
+	}
+	return opts, nil
+}
+
+// fleetPlan holds the changes required to reconcile the clusters with the fleet file.
+type fleetPlan struct {
+	spec    *fleetSpec
+	changes []printer.FleetChange
+	// featureSets are the feature sets the clusters to reconfigure are reconfigured with
+	featureSets map[string][]clustermodel.FeatureSet
+}
+
+func (p *fleetPlan) cluster(name string) *fleetCluster {
+	for i := range p.spec.Clusters {
+		if p.spec.Clusters[i].Name == name {
+			return &p.spec.Clusters[i]
+		}
+	}
+	return nil
+}
+
+// pending returns the changes that have to be applied.
+func (p *fleetPlan) pending() []printer.FleetChange {
+	var changes []printer.FleetChange
+	for _, c := range p.changes {
+		switch c.Action {
+		case printer.FleetActionImport, printer.FleetActionReconfigure, printer.FleetActionRemove:
+			changes = append(changes, c)
+		}
+	}
+	return changes
+}
+
+// planError returns an error listing the clusters whose changes couldn't be planned.
+func (p *fleetPlan) planError() error {
+	var failed []string
+	for _, c := range p.changes {
+		if c.Action == printer.FleetActionError {
+			failed = append(failed, c.Cluster)
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return exitcode.New(exitcode.GeneralError, fmt.Errorf("failed to plan the changes of %d clusters: %s", len(failed), strings.Join(failed, ", ")))
+}
+
+// planFleet compares the clusters of the organization with the fleet file. Missing clusters are
+// imported and the clusters whose feature sets have drifted are reconfigured. The clusters that are
+// not declared in the fleet file are removed only if prune is true, unless they are protected.
+func planFleet(f *config.Factory, spec *fleetSpec, prune bool, catalogCluster string, concurrency int) (*fleetPlan, error) {
+	clusters, err := listClusters(f, clustermodel.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list clusters. Reason: %w", err)
+	}
+	existing := sets.New[string]()
+	for i := range clusters.Items {
+		existing.Insert(clusters.Items[i].Spec.Name)
+	}
+
+	// the catalog is read once for all the clusters to import
+	catalog := newCatalogSource(f, catalogCluster)
+	plan := &fleetPlan{spec: spec, featureSets: map[string][]clustermodel.FeatureSet{}}
+	changes := make([]*printer.FleetChange, len(spec.Clusters))
+	featureSets := make([][]clustermodel.FeatureSet, len(spec.Clusters))
+	var g errgroup.Group
+	g.SetLimit(concurrency)
+	for i := range spec.Clusters {
+		desired := &spec.Clusters[i]
+		g.Go(func() error {
+			var err error
+			if existing.Has(desired.Name) {
+				changes[i], featureSets[i], err = planReconfigure(f, desired)
+			} else {
+				changes[i], err = planImport(desired, catalog)
+			}
+			if err != nil {
+				changes[i] = &printer.FleetChange{Cluster: desired.Name, Action: printer.FleetActionError, Details: []string{err.Error()}}
+			}
+			return nil
+		})
+	}
+	_ = g.Wait()
+	for i, c := range changes {
+		if c == nil {
+			continue
+		}
+		plan.changes = append(plan.changes, *c)
+		if c.Action == printer.FleetActionReconfigure {
+			plan.featureSets[c.Cluster] = featureSets[i]
+		}
+	}
+
+	for _, name := range sets.List(existing) {
+		if plan.cluster(name) != nil {
+			continue
+		}
+		change := printer.FleetChange{Cluster: name, Action: printer.FleetActionUnmanaged, Details: []string{"not declared in the fleet file. Use --prune to remove it"}}
+		if prune {
+			protected, err := config.IsClusterProtected(name)
+			if err != nil {
+				return nil, err
+			}
+			if protected {
+				change.Details = []string{"protected in the current context. It will not be pruned"}
+			} else {
+				change = printer.FleetChange{Cluster: name, Action: printer.FleetActionRemove}
+			}
+		}
+		plan.changes = append(plan.changes, change)
+	}
+	return plan, nil
+}
+
+func planImport(desired *fleetCluster, catalog *catalogSource) (*printer.FleetChange, error) {
+	if err := catalog.validate(desired.ClusterProfile, desired.featureSets()); err != nil {
+		return nil, err
+	}
+	change := &printer.FleetChange{Cluster: desired.Name, Action: printer.FleetActionImport}
+	for _, fs := range desired.featureSets() {
+		for _, feature := range fs.Features {
+			change.Details = append(change.Details, fmt.Sprintf("+ %s/%s", fs.Name, feature))
+		}
+	}
+	return change, nil
+}
+
+// planReconfigure compares the enabled features of the cluster with the declared ones. It returns
+// nil if the feature sets are not declared or have not drifted. Otherwise, it also returns the
+// feature sets to reconfigure the cluster with: the enabled ones with the declared ones overlaid.
+func planReconfigure(f *config.Factory, desired *fleetCluster) (*printer.FleetChange, []clustermodel.FeatureSet, error) {
+	if desired.FeatureSets == nil {
+		return nil, nil, nil
+	}
+	kc, err := newCatalogClient(f, desired.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	catalog, err := loadFeatureCatalog(kc)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := catalog.validate(desired.featureSets()); err != nil {
+		return nil, nil, err
+	}
+
+	current := catalog.enabledFeatureSets()
+	details := featureSetDrift(current, desired.FeatureSets)
+	if len(details) == 0 {
+		return nil, nil, nil
+	}
+	change := &printer.FleetChange{Cluster: desired.Name, Action: printer.FleetActionReconfigure, Details: details}
+	return change, overlayFeatureSets(current, desired.FeatureSets), nil
+}
+
+// featureSetDrift returns the features to enable (+) and disable (-) to bring the declared feature
+// sets to their declared state. The feature sets that are not declared are left as they are.
+func featureSetDrift(current []clustermodel.FeatureSet, declared map[string][]string) []string {
+	enabled := map[string]sets.Set[string]{}
+	for _, fs := range current {
+		enabled[fs.Name] = sets.New(fs.Features...)
+	}
+
+	var details []string
+	for _, name := range sets.List(sets.KeySet(declared)) {
+		have, want := enabled[name], sets.New(declared[name]...)
+		if have == nil {
+			have = sets.New[string]()
+		}
+		for _, feature := range sets.List(want.Difference(have)) {
+			details = append(details, fmt.Sprintf("+ %s/%s", name, feature))
+		}
+		for _, feature := range sets.List(have.Difference(want)) {
+			details = append(details, fmt.Sprintf("- %s/%s", name, feature))
+		}
+	}
+	return details
+}
+
+// overlayFeatureSets returns the current feature sets with the declared ones replacing them.
+func overlayFeatureSets(current []clustermodel.FeatureSet, declared map[string][]string) []clustermodel.FeatureSet {
+	desired := map[string]sets.Set[string]{}
+	for _, fs := range current {
+		desired[fs.Name] = sets.New(fs.Features...)
+	}
+	for name, features := range declared {
+		desired[name] = sets.New(features...)
+	}
+
+	featureSets := make([]clustermodel.FeatureSet, 0, len(desired))
+	for _, name := range sets.List(sets.KeySet(desired)) {
+		if desired[name].Len() == 0 {
+			continue
+		}
+		featureSets = append(featureSets, clustermodel.FeatureSet{Name: name, Features: sets.List(desired[name])})
+	}
+	return featureSets
+}
+
+// applyFleetPlan runs the pending changes of the plan in parallel.
+func applyFleetPlan(f *config.Factory, plan *fleetPlan, concurrency int, jf jobFlags) error {
+	pending := plan.pending()
+	actions := make(map[string]string, len(pending))
+	names := make([]string, 0, len(pending))
+	for _, c := range pending {
+		actions[c.Cluster] = c.Action
+		names = append(names, c.Cluster)
+	}
+	slices.Sort(names)
+
+	return runBulkJob(f, "apply", names, concurrency, func(c *ace.Client, nc *natsConn, cluster, prefix string) error {
+		desired := plan.cluster(cluster)
+		job := natsJob{
+			jobFlags: jf,
+			cluster:  cluster,
+			prefix:   prefix,
+		}
+		switch actions[cluster] {
+		case printer.FleetActionImport:
+			opts, err := desired.importOptions(plan.spec.dir)
+			if err != nil {
+				return err
+			}
+			job.action = "import"
+			job.start = func(responseID string) error {
+				_, err := c.ImportCluster(opts, responseID)
+				return err
+			}
+		case printer.FleetActionReconfigure:
+			opts := clustermodel.ReconfigureOptions{
+				BasicInfo:  clustermodel.BasicInfo{Name: cluster},
+				Components: desired.components(),
+			}
+			opts.Components.FeatureSets = plan.featureSets[cluster]
+			job.action = "reconfigure"
+			job.start = func(responseID string) error {
+				_, err := c.ReconfigureCluster(opts, responseID)
+				return err
+			}
+		case printer.FleetActionRemove:
+			opts := pruneRemovalOptions(cluster)
+			job.action = "removal"
+			job.start = func(responseID string) error {
+				return c.RemoveCluster(opts, responseID)
+			}
+		}
+
+		err := runNATSJob(f, nc, job)
+		if job.action == "removal" && errors.Is(err, ace.ErrNotFound) {
+			printer.Progressf("%sCluster has been removed already.\n", prefix)
+			return nil
+		}
+		return err
+	})
+}
+
+// pruneRemovalOptions returns the options used to remove the clusters that are not declared in
+// the fleet file. They match the defaults of 'ace cluster remove'.
+func pruneRemovalOptions(cluster string) clustermodel.RemovalOptions {
+	return clustermodel.RemovalOptions{
+		Name: cluster,
+		Components: clustermodel.ComponentOptions{
+			FluxCD:      true,
+			FeatureSets: defaultFeatureSet,
+		},
+	}
+}
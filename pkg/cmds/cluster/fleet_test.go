@@ -0,0 +1,154 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Community License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Community-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go.bytebuilders.dev/cli/pkg/exitcode"
+	clustermodel "go.bytebuilders.dev/resource-model/apis/cluster"
+)
+
+func writeFleetFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "fleet.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadFleetSpec(t *testing.T) {
+	path := writeFleetFile(t, `clusters:
+- name: prod
+  provider: EKS
+  kubeconfig: prod.yaml
+  featureSets:
+    opscenter-core: [kube-ui-server, kube-ui-server]
+- name: dev
+  provider: Generic
+`)
+	spec, err := loadFleetSpec(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(spec.Clusters) != 2 || spec.Clusters[0].Name != "prod" || spec.Clusters[1].Name != "dev" {
+		t.Fatalf("unexpected clusters: %+v", spec.Clusters)
+	}
+	if spec.dir != filepath.Dir(path) {
+		t.Errorf("expected dir %s, got %s", filepath.Dir(path), spec.dir)
+	}
+	want := []clustermodel.FeatureSet{{Name: "opscenter-core", Features: []string{"kube-ui-server"}}}
+	if got := spec.Clusters[0].featureSets(); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected feature sets %v, got %v", want, got)
+	}
+}
+
+func TestLoadFleetSpecInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		reason  string
+	}{
+		{"unknown field", "clusters:\n- name: prod\n  provider: EKS\n  nodes: 3\n", "unknown field"},
+		{"missing name", "clusters:\n- provider: EKS\n", "must have a name"},
+		{"duplicate name", "clusters:\n- name: prod\n  provider: EKS\n- name: prod\n  provider: GKE\n", "more than once"},
+		{"missing provider", "clusters:\n- name: prod\n", "provider of cluster prod is missing"},
+		{"portable credentials without kubeconfig", "clusters:\n- name: prod\n  provider: EKS\n  portableCredentials: true\n", "requires kubeconfig"},
+		{"invalid eks auth mode", "clusters:\n- name: prod\n  provider: EKS\n  eksAuthMode: Unknown\n", "invalid cluster prod"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadFleetSpec(writeFleetFile(t, tt.content))
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if !strings.Contains(err.Error(), tt.reason) {
+				t.Errorf("expected the error to contain %q, got %q", tt.reason, err)
+			}
+			if code := exitcode.Code(err); code != exitcode.Validation {
+				t.Errorf("expected exit code %d, got %d", exitcode.Validation, code)
+			}
+		})
+	}
+}
+
+func TestFeatureSetDrift(t *testing.T) {
+	current := []clustermodel.FeatureSet{
+		{Name: "opscenter-core", Features: []string{"kube-ui-server", "license-proxyserver"}},
+		{Name: "opscenter-monitoring", Features: []string{"kube-prometheus-stack"}},
+	}
+	tests := []struct {
+		name     string
+		declared map[string][]string
+		want     []string
+	}{
+		{
+			name:     "no drift",
+			declared: map[string][]string{"opscenter-core": {"license-proxyserver", "kube-ui-server"}},
+		},
+		{
+			name:     "undeclared feature sets are ignored",
+			declared: map[string][]string{},
+		},
+		{
+			name:     "features added and removed",
+			declared: map[string][]string{"opscenter-core": {"kube-ui-server", "opscenter-features"}},
+			want:     []string{"+ opscenter-core/opscenter-features", "- opscenter-core/license-proxyserver"},
+		},
+		{
+			name:     "new feature set",
+			declared: map[string][]string{"opscenter-backup": {"kubestash"}},
+			want:     []string{"+ opscenter-backup/kubestash"},
+		},
+		{
+			name:     "feature set disabled",
+			declared: map[string][]string{"opscenter-monitoring": {}},
+			want:     []string{"- opscenter-monitoring/kube-prometheus-stack"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := featureSetDrift(current, tt.declared); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestOverlayFeatureSets(t *testing.T) {
+	current := []clustermodel.FeatureSet{
+		{Name: "opscenter-core", Features: []string{"kube-ui-server", "license-proxyserver"}},
+		{Name: "opscenter-monitoring", Features: []string{"kube-prometheus-stack"}},
+	}
+	declared := map[string][]string{
+		"opscenter-backup":     {"kubestash"},
+		"opscenter-core":       {"kube-ui-server"},
+		"opscenter-monitoring": {},
+	}
+	want := []clustermodel.FeatureSet{
+		{Name: "opscenter-backup", Features: []string{"kubestash"}},
+		{Name: "opscenter-core", Features: []string{"kube-ui-server"}},
+	}
+	if got := overlayFeatureSets(current, declared); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
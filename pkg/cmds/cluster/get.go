@@ -20,7 +20,9 @@ import (
 	"errors"
 	"fmt"
 
+	"go.bytebuilders.dev/cli/pkg/completion"
 	"go.bytebuilders.dev/cli/pkg/config"
+	"go.bytebuilders.dev/cli/pkg/exitcode"
 	"go.bytebuilders.dev/cli/pkg/printer"
 	ace "go.bytebuilders.dev/client"
 	clustermodel "go.bytebuilders.dev/resource-model/apis/cluster"
@@ -39,8 +41,7 @@ func newCmdGet(f *config.Factory) *cobra.Command {
 			cluster, err := getCluster(f, opts)
 			if err != nil {
 				if errors.Is(err, ace.ErrNotFound) {
-					fmt.Println("Cluster does not exist.")
-					return nil
+					return &exitcode.NotFoundError{Resource: "cluster", Name: opts.Name}
 				}
 				return fmt.Errorf("failed to get the cluster information. Reason: %w", err)
 			}
@@ -48,6 +49,8 @@ func newCmdGet(f *config.Factory) *cobra.Command {
 		},
 	}
 	cmd.Flags().StringVar(&opts.Name, "name", "", "Name of the cluster to get")
+	_ = cmd.RegisterFlagCompletionFunc("name", completion.ClusterNames(f))
+	cmd.Flags().StringSliceVar(&printer.Columns, "columns", nil, "Comma-separated list of the columns to show in the table (e.g. name,phase,version,nodes)")
 	return cmd
 }
 
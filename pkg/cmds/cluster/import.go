@@ -19,14 +19,16 @@ package cluster
 import (
 	"fmt"
 	"os"
+	"sort"
 	"strings"
-	"sync"
 
+	"go.bytebuilders.dev/cli/pkg/cmds/utils"
+	"go.bytebuilders.dev/cli/pkg/completion"
 	"go.bytebuilders.dev/cli/pkg/config"
+	"go.bytebuilders.dev/cli/pkg/exitcode"
 	"go.bytebuilders.dev/cli/pkg/printer"
 	clustermodel "go.bytebuilders.dev/resource-model/apis/cluster"
 
-	"github.com/rs/xid"
 	"github.com/spf13/cobra"
 )
 
@@ -34,22 +36,44 @@ func newCmdImport(f *config.Factory) *cobra.Command {
 	opts := clustermodel.ImportOptions{}
 	var featureSet map[string]string
 	var kubeConfigPath string
+	var portableCredentials bool
+	var catalogCluster string
+	jf := jobFlags{}
 	cmd := &cobra.Command{
 		Use:               "import",
 		Short:             "Import a cluster to ACE platform",
 		DisableAutoGenTag: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if portableCredentials && kubeConfigPath == "" {
+				return exitcode.Validationf("--portable-credentials requires --kubeconfig")
+			}
+			if err := validateEksAuthMode(opts.Provider.EksAuthMode); err != nil {
+				return err
+			}
 			if kubeConfigPath != "" {
 				data, err := os.ReadFile(kubeConfigPath)
 				if err != nil {
 					return fmt.Errorf("failed to read Kubeconfig file. Reason: %w", err)
 				}
+				if portableCredentials {
+					data, err = utils.GeneratePortableKubeConfig(data)
+					if err != nil {
+						return fmt.Errorf("failed to generate portable kubeconfig. Reason: %w", err)
+					}
+				}
 				opts.Provider.KubeConfig = string(data)
 			}
 
 			opts.Components.FeatureSets = getFeatureSetsInfo(featureSet)
+			var desiredFeatureSets []clustermodel.FeatureSet
+			if len(featureSet) > 0 {
+				desiredFeatureSets = opts.Components.FeatureSets
+			}
+			if err := validateComponents(f, catalogCluster, opts.Components.ClusterProfile, desiredFeatureSets); err != nil {
+				return err
+			}
 
-			err := importCluster(f, opts)
+			err := importCluster(f, opts, jf)
 			if err != nil {
 				return fmt.Errorf("failed to import cluster. Reason: %w", err)
 			}
@@ -57,52 +81,50 @@ func newCmdImport(f *config.Factory) *cobra.Command {
 		},
 	}
 	cmd.Flags().StringVar(&opts.Provider.Name, "provider", "", "Name of the cluster provider")
+	_ = cmd.RegisterFlagCompletionFunc("provider", completion.ProviderNames)
 	cmd.Flags().StringVar(&opts.Provider.Credential, "credential", "", "Name of the credential with access to the provider APIs")
 	cmd.Flags().StringVar(&opts.Provider.ClusterID, "id", "", "Provider specific cluster ID")
 	cmd.Flags().StringVar(&opts.Provider.Project, "project", "", "Project where the cluster belong (use for GKE)")
 	cmd.Flags().StringVar(&opts.Provider.Region, "region", "", "Region or location of the cluster")
 	cmd.Flags().StringVar(&opts.Provider.ResourceGroup, "resource-group", "", "Resource group of the cluster (use for AKS)")
+	cmd.Flags().StringVar((*string)(&opts.Provider.EksAuthMode), "eks-auth-mode", "", "Authentication mode for the workloads of the cluster (any of IRSA,PodIdentity) (use for EKS)")
 	cmd.Flags().StringVar(&kubeConfigPath, "kubeconfig", "", "Path of the kubeconfig file")
+	cmd.Flags().BoolVar(&portableCredentials, "portable-credentials", false, "Use the kubeconfig credentials to create a dedicated ServiceAccount in the cluster and import using its token (use for exec plugin based kubeconfigs)")
 
 	cmd.Flags().StringVar(&opts.BasicInfo.DisplayName, "display-name", "", "Display name of the cluster")
 	cmd.Flags().StringVar(&opts.BasicInfo.Name, "name", "", "Unique name across all imported clusters of all provider")
 	cmd.Flags().BoolVar(&opts.Components.FluxCD, "install-fluxcd", true, "Specify whether to install FluxCD or not (default true).")
 	cmd.Flags().BoolVar(&opts.Components.AllFeatures, "all-features", false, "Install all features")
-	cmd.Flags().StringToStringVar(&featureSet, "featureset", featureSet, "List of features")
+	cmd.Flags().StringToStringVar(&featureSet, "featureset", featureSet, "List of features to install per feature set (e.g. opscenter-core=kube-ui-server). See 'ace cluster features list'")
+	cmd.Flags().StringVar(&opts.Components.ClusterProfile, "cluster-profile", "", "Name of the cluster profile to use (see 'ace cluster profiles list')")
+	cmd.Flags().BoolVar(&opts.Components.SpokeComponents, "spoke-components", false, "Install the components required to register the cluster as a spoke of a hub cluster")
+	cmd.Flags().StringVar(&catalogCluster, "catalog-cluster", "", "Name of the cluster to validate the cluster profile and feature sets against (default is the first active cluster)")
+	_ = cmd.RegisterFlagCompletionFunc("catalog-cluster", completion.ClusterNames(f))
+	jf.addFlags(cmd)
 	return cmd
 }
 
-func importCluster(f *config.Factory, opts clustermodel.ImportOptions) error {
-	fmt.Println("Importing cluster......")
+func importCluster(f *config.Factory, opts clustermodel.ImportOptions, jf jobFlags) error {
+	printer.Progressf("Importing cluster......\n")
 	c, err := f.Client()
 	if err != nil {
 		return err
 	}
-	nc, err := c.NewNatsConnection("ace-cli")
+	nc, err := newNATSConn(c)
 	if err != nil {
 		return err
 	}
 	defer nc.Close() // nolint:errcheck
 
-	responseID := xid.New().String()
-	wg := sync.WaitGroup{}
-	wg.Add(1)
-	done := f.Canceller()
-	go func() {
-		err := printer.PrintNATSJobSteps(&wg, nc, responseID, done)
-		if err != nil {
-			fmt.Println("Failed to log the import steps. Reason: ", err)
-		}
-	}()
-
-	_, err = c.ImportCluster(opts, responseID)
-	if err != nil {
-		close(done)
-		return err
-	}
-	wg.Wait()
-
-	return nil
+	return runNATSJob(f, nc, natsJob{
+		jobFlags: jf,
+		action:   "import",
+		cluster:  opts.BasicInfo.Name,
+		start: func(responseID string) error {
+			_, err := c.ImportCluster(opts, responseID)
+			return err
+		},
+	})
 }
 
 func getFeatureSetsInfo(featureSets map[string]string) []clustermodel.FeatureSet {
@@ -119,6 +141,9 @@ func getFeatureSetsInfo(featureSets map[string]string) []clustermodel.FeatureSet
 
 		desiredFeatureSets = append(desiredFeatureSets, featureSet)
 	}
+	sort.Slice(desiredFeatureSets, func(i, j int) bool {
+		return desiredFeatureSets[i].Name < desiredFeatureSets[j].Name
+	})
 
 	return desiredFeatureSets
 }
@@ -0,0 +1,242 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Community License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Community-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"go.bytebuilders.dev/cli/pkg/config"
+	"go.bytebuilders.dev/cli/pkg/exitcode"
+	"go.bytebuilders.dev/cli/pkg/printer"
+	ace "go.bytebuilders.dev/client"
+	clustermodel "go.bytebuilders.dev/resource-model/apis/cluster"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	nodeRoleLabelPrefix = "node-role.kubernetes.io/"
+	// maxKubeletSkew is the number of minor versions a kubelet may be older than the API server
+	maxKubeletSkew = 3
+	// maxFleetSkew is the number of minor versions the clusters of the fleet may be apart
+	maxFleetSkew = 2
+)
+
+func newCmdInventory(f *config.Factory) *cobra.Command {
+	var targets clusterTargets
+	cmd := &cobra.Command{
+		Use:               "inventory",
+		Short:             "Collect the inventory of the nodes and workloads of the clusters",
+		Long:              "Connect to every cluster in parallel and collect the nodes, their versions and capacity along with the namespace and workload counts. All the clusters are targeted if none is selected.",
+		DisableAutoGenTag: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(targets.names) == 0 && targets.selector == "" {
+				targets.all = true
+			}
+			clusters, err := targets.resolve(f)
+			if err != nil {
+				return err
+			}
+			c, err := f.Client()
+			if err != nil {
+				return err
+			}
+
+			inventory := &printer.FleetInventory{
+				Clusters: make([]printer.ClusterInventory, len(clusters)),
+			}
+			var g errgroup.Group
+			g.SetLimit(targets.concurrency)
+			for i, name := range clusters {
+				g.Go(func() error {
+					inv, err := collectInventory(c, name)
+					if err != nil {
+						inv = &printer.ClusterInventory{Cluster: name, Error: err.Error()}
+					}
+					inventory.Clusters[i] = *inv
+					return nil
+				})
+			}
+			_ = g.Wait()
+			inventory.Warnings = fleetSkewWarnings(inventory.Clusters)
+
+			if err := printer.PrintInventory(inventory); err != nil {
+				return err
+			}
+
+			var failed []string
+			for _, inv := range inventory.Clusters {
+				if inv.Error != "" {
+					failed = append(failed, inv.Cluster)
+				}
+			}
+			if len(failed) > 0 {
+				return exitcode.New(exitcode.GeneralError, fmt.Errorf("failed to collect the inventory of %d of %d clusters: %s", len(failed), len(clusters), strings.Join(failed, ", ")))
+			}
+			return nil
+		},
+	}
+	targets.addFlags(f, cmd)
+	return cmd
+}
+
+func collectInventory(c *ace.Client, cluster string) (*printer.ClusterInventory, error) {
+	cc, err := c.GetClusterClientConfig(clustermodel.GetOptions{Name: cluster})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client config. Reason: %w", err)
+	}
+	restConfig, err := cc.ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	kc, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	inv := &printer.ClusterInventory{
+		Cluster:   cluster,
+		Roles:     map[string]int{},
+		Workloads: map[string]int{},
+	}
+	info, err := kc.Discovery().ServerVersion()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the server version. Reason: %w", err)
+	}
+	inv.KubernetesVersion = info.GitVersion
+
+	nodes, err := kc.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes. Reason: %w", err)
+	}
+	inv.Nodes = len(nodes.Items)
+	cpu, memory := resource.Quantity{}, resource.Quantity{}
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		for _, role := range nodeRoles(node) {
+			inv.Roles[role]++
+		}
+		inv.KubeletVersions = append(inv.KubeletVersions, node.Status.NodeInfo.KubeletVersion)
+		inv.Platforms = append(inv.Platforms, node.Status.NodeInfo.OperatingSystem+"/"+node.Status.NodeInfo.Architecture)
+		cpu.Add(node.Status.Allocatable[corev1.ResourceCPU])
+		memory.Add(node.Status.Allocatable[corev1.ResourceMemory])
+	}
+	inv.AllocatableCPU = cpu.String()
+	inv.AllocatableMemory = fmt.Sprintf("%.1fGi", float64(memory.Value())/(1<<30))
+	inv.Warnings = kubeletSkewWarnings(inv.KubernetesVersion, inv.KubeletVersions)
+	slices.Sort(inv.KubeletVersions)
+	inv.KubeletVersions = slices.Compact(inv.KubeletVersions)
+	slices.Sort(inv.Platforms)
+	inv.Platforms = slices.Compact(inv.Platforms)
+
+	namespaces, err := kc.CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces. Reason: %w", err)
+	}
+	inv.Namespaces = len(namespaces.Items)
+
+	deployments, err := kc.AppsV1().Deployments(metav1.NamespaceAll).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments. Reason: %w", err)
+	}
+	inv.Workloads["deployments"] = len(deployments.Items)
+	statefulSets, err := kc.AppsV1().StatefulSets(metav1.NamespaceAll).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list statefulsets. Reason: %w", err)
+	}
+	inv.Workloads["statefulsets"] = len(statefulSets.Items)
+	daemonSets, err := kc.AppsV1().DaemonSets(metav1.NamespaceAll).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list daemonsets. Reason: %w", err)
+	}
+	inv.Workloads["daemonsets"] = len(daemonSets.Items)
+	return inv, nil
+}
+
+// nodeRoles returns the roles of the node from its node-role.kubernetes.io/<role> labels.
+func nodeRoles(node *corev1.Node) []string {
+	var roles []string
+	for k := range node.Labels {
+		if role, found := strings.CutPrefix(k, nodeRoleLabelPrefix); found && role != "" {
+			roles = append(roles, role)
+		}
+	}
+	if len(roles) == 0 {
+		roles = append(roles, "<none>")
+	}
+	return roles
+}
+
+// kubeletSkewWarnings reports the kubelets that are newer than the API server or older than the
+// supported version skew.
+func kubeletSkewWarnings(serverVersion string, kubeletVersions []string) []string {
+	server, err := version.ParseGeneric(serverVersion)
+	if err != nil {
+		return nil
+	}
+	var warnings []string
+	seen := map[string]bool{}
+	for _, v := range kubeletVersions {
+		if seen[v] {
+			continue
+		}
+		seen[v] = true
+		kubelet, err := version.ParseGeneric(v)
+		if err != nil {
+			continue
+		}
+		switch {
+		case kubelet.Minor() > server.Minor():
+			warnings = append(warnings, fmt.Sprintf("kubelet %s is newer than the API server %s", v, serverVersion))
+		case server.Minor()-kubelet.Minor() > maxKubeletSkew:
+			warnings = append(warnings, fmt.Sprintf("kubelet %s is more than %d minor versions older than the API server %s", v, maxKubeletSkew, serverVersion))
+		}
+	}
+	return warnings
+}
+
+// fleetSkewWarnings reports if the Kubernetes versions of the clusters are too far apart.
+func fleetSkewWarnings(clusters []printer.ClusterInventory) []string {
+	var oldest, newest *printer.ClusterInventory
+	var oldestVersion, newestVersion *version.Version
+	for i := range clusters {
+		v, err := version.ParseGeneric(clusters[i].KubernetesVersion)
+		if err != nil {
+			continue
+		}
+		if oldestVersion == nil || v.LessThan(oldestVersion) {
+			oldest, oldestVersion = &clusters[i], v
+		}
+		if newestVersion == nil || newestVersion.LessThan(v) {
+			newest, newestVersion = &clusters[i], v
+		}
+	}
+	if oldest == nil || newestVersion.Minor()-oldestVersion.Minor() <= maxFleetSkew {
+		return nil
+	}
+	return []string{fmt.Sprintf("the clusters are more than %d minor versions apart: %s runs %s and %s runs %s",
+		maxFleetSkew, oldest.Cluster, oldest.KubernetesVersion, newest.Cluster, newest.KubernetesVersion)}
+}
@@ -0,0 +1,218 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Community License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Community-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"os/signal"
+	"sync"
+	"time"
+
+	"go.bytebuilders.dev/cli/pkg/clustercache"
+	"go.bytebuilders.dev/cli/pkg/config"
+	"go.bytebuilders.dev/cli/pkg/exitcode"
+	"go.bytebuilders.dev/cli/pkg/journal"
+	"go.bytebuilders.dev/cli/pkg/printer"
+	ace "go.bytebuilders.dev/client"
+
+	"github.com/nats-io/nats.go"
+	"github.com/rs/xid"
+	"github.com/spf13/cobra"
+	"k8s.io/klog/v2"
+)
+
+// jobFlags holds the flags shared by the commands that run a NATS job.
+type jobFlags struct {
+	noWait            bool
+	inactivityTimeout time.Duration
+}
+
+func (o *jobFlags) addFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&o.noWait, "no-wait", false, "Start the job and return immediately, printing its ID. Use 'ace job attach <id>' to follow its progress")
+	cmd.Flags().DurationVar(&o.inactivityTimeout, "inactivity-timeout", 0, "Stop waiting for the job if it doesn't report any progress within this duration (e.g. 10m). Zero means no timeout")
+}
+
+// natsConn is a NATS connection shared by the jobs of a command. The connections created by the
+// ACE client don't reconnect on their own, so a new one is created once the current one is closed.
+type natsConn struct {
+	mu sync.Mutex
+	c  *ace.Client
+	nc *nats.Conn
+}
+
+func newNATSConn(c *ace.Client) (*natsConn, error) {
+	nc, err := c.NewNatsConnection("ace-cli")
+	if err != nil {
+		return nil, err
+	}
+	return &natsConn{c: c, nc: nc}, nil
+}
+
+func (n *natsConn) conn() *nats.Conn {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.nc
+}
+
+// reconnect returns the current connection, replacing it first if it has been closed.
+func (n *natsConn) reconnect() (*nats.Conn, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if !n.nc.IsClosed() {
+		return n.nc, nil
+	}
+	nc, err := n.c.NewNatsConnection("ace-cli")
+	if err != nil {
+		return nil, err
+	}
+	n.nc = nc
+	return nc, nil
+}
+
+func (n *natsConn) Close() error {
+	n.conn().Close()
+	return nil
+}
+
+// natsJob describes a job that publishes its progress on NATS.
+type natsJob struct {
+	jobFlags
+	// action is used in the messages printed about the job (e.g. import, removal)
+	action string
+	// cluster is the name of the cluster the job runs against
+	cluster string
+	// prefix is prepended to every printed step
+	prefix string
+	// start starts the job, asking the server to publish its steps under responseID
+	start func(responseID string) error
+}
+
+// reportedError wraps the error of a job whose result has already been printed as a JobEvent
+// with -o json, so that it isn't printed again by runBulkJob.
+type reportedError struct {
+	err error
+}
+
+func (e *reportedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *reportedError) Unwrap() error {
+	return e.err
+}
+
+// reportResult prints the result event of a job that has ended without the job reporting it.
+func (job natsJob) reportResult(responseID, status string, err error) error {
+	if printer.OutputFormat != "json" {
+		return err
+	}
+	_ = printer.PrintJobEvent(printer.JobEvent{
+		Type:    printer.JobEventResult,
+		JobID:   responseID,
+		Cluster: job.cluster,
+		Status:  status,
+		Message: err.Error(),
+	})
+	return &reportedError{err: err}
+}
+
+// runNATSJob starts the job and waits until it has finished, printing its steps as they are
+// published on NATS. If the job fails, the returned error wraps a *printer.JobFailedError and
+// exits with RemoteFailure, as does losing track of the job before it has finished. With --no-wait
+// only the job ID is printed. The job is recorded in the local journal.
+func runNATSJob(f *config.Factory, nc *natsConn, job natsJob) error {
+	// the job changes the clusters, so they have to be listed again next time
+	defer invalidateClusterCache()
+
+	responseID := xid.New().String()
+	jw, err := journal.Start(responseID, job.action, job.cluster)
+	if err != nil {
+		klog.Warningf("failed to record job %s in the local journal. Reason: %v", responseID, err)
+	}
+
+	if job.noWait {
+		err := job.start(responseID)
+		if err != nil {
+			jw.Finish(journal.StatusError, err)
+			return job.reportResult(responseID, printer.JobStatusError, err)
+		}
+		jw.Finish(journal.StatusDetached, nil)
+		if printer.OutputFormat == "json" {
+			return printer.PrintJobEvent(printer.JobEvent{
+				Type:    printer.JobEventStarted,
+				JobID:   responseID,
+				Cluster: job.cluster,
+			})
+		}
+		printer.Progressf("%sStarted %s job %s. Run 'ace job attach %s' to follow its progress.\n", job.prefix, job.action, responseID, responseID)
+		return nil
+	}
+
+	// subscribe before starting the job, so that no step is missed
+	w, err := printer.SubscribeNATSJobSteps(nc.conn(), responseID, printer.JobStepsOptions{
+		Prefix:            job.prefix,
+		Cluster:           job.cluster,
+		OnMessage:         jw.RecordStep,
+		InactivityTimeout: job.inactivityTimeout,
+		Reconnect:         nc.reconnect,
+	})
+	if err != nil {
+		jw.Finish(journal.StatusError, err)
+		return job.reportResult(responseID, printer.JobStatusError, err)
+	}
+	err = job.start(responseID)
+	if err != nil {
+		_ = w.Close()
+		jw.Finish(journal.StatusError, err)
+		return job.reportResult(responseID, printer.JobStatusError, err)
+	}
+
+	done := f.Canceller()
+	defer signal.Stop(done)
+	err = w.Watch(done)
+	var failure *printer.JobFailedError
+	if errors.As(err, &failure) {
+		jw.Finish(journal.StatusFailed, failure)
+		// the result has been printed by the watcher
+		err = exitcode.New(exitcode.RemoteFailure, failure)
+		if printer.OutputFormat == "json" {
+			err = &reportedError{err: err}
+		}
+		return err
+	}
+	if errors.Is(err, printer.ErrJobInactive) {
+		jw.Close()
+		return job.reportResult(responseID, printer.JobStatusUnknown, exitcode.New(exitcode.Timeout, fmt.Errorf("%w. Run 'ace job attach %s' to keep following it", err, responseID)))
+	}
+	if errors.Is(err, exitcode.ErrCancelled) {
+		jw.Close()
+		return job.reportResult(responseID, printer.JobStatusUnknown, fmt.Errorf("%w. The %s job keeps running, run 'ace job attach %s' to follow it", err, job.action, responseID))
+	}
+	if err != nil {
+		jw.Finish(journal.StatusUnknown, err)
+		return job.reportResult(responseID, printer.JobStatusUnknown, exitcode.New(exitcode.RemoteFailure, fmt.Errorf("failed to follow the %s job %s, its result is unknown. Run 'ace job attach %s' to check it. Reason: %w", job.action, responseID, responseID, err)))
+	}
+	jw.Finish(journal.StatusSuccess, nil)
+	return nil
+}
+
+func invalidateClusterCache() {
+	if err := clustercache.Invalidate(); err != nil {
+		klog.V(3).Infof("failed to invalidate the cluster cache. Reason: %v", err)
+	}
+}
@@ -18,34 +18,57 @@ package cluster
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
+	"go.bytebuilders.dev/cli/pkg/clustercache"
+	"go.bytebuilders.dev/cli/pkg/completion"
 	"go.bytebuilders.dev/cli/pkg/config"
 	"go.bytebuilders.dev/cli/pkg/printer"
 	clustermodel "go.bytebuilders.dev/resource-model/apis/cluster"
 	"go.bytebuilders.dev/resource-model/apis/cluster/v1alpha1"
 
 	"github.com/spf13/cobra"
+	"k8s.io/klog/v2"
 )
 
 func newCmdList(f *config.Factory) *cobra.Command {
 	listOptions := clustermodel.ListOptions{}
+	var watch, includeStatus, cached bool
+	var interval, cacheTTL time.Duration
 	cmd := &cobra.Command{
 		Use:               "list",
 		Short:             "List cluster managed by ACE platform",
 		DisableAutoGenTag: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			clusters, err := listClusters(f, listOptions)
+			if watch {
+				return watchClusters(f, listOptions, interval)
+			}
+			var clusters *v1alpha1.ClusterInfoList
+			var err error
+			if cached {
+				clusters, err = cachedClusters(f, listOptions, cacheTTL)
+			} else {
+				clusters, err = listClusters(f, listOptions)
+			}
 			if err != nil {
 				return fmt.Errorf("failed to list clusters. Reason: %w", err)
 			}
-			if len(clusters.Items) == 0 {
+			if len(clusters.Items) == 0 && printer.IsTableOutput() {
 				fmt.Println("No cluster found.")
 				return nil
 			}
-			return printer.PrintClusterList(clusters.Items)
+			return printer.PrintClusterList(clusters.Items, includeStatus)
 		},
 	}
 	cmd.Flags().StringVar(&listOptions.Provider, "provider", "", "List cluster only for this provider")
+	_ = cmd.RegisterFlagCompletionFunc("provider", completion.ProviderNames)
+	cmd.Flags().BoolVarP(&watch, "watch", "w", false, "Watch the clusters and highlight the ones whose phase has changed. Use '-o json' or '-o ndjson' to print the changes as NDJSON events")
+	cmd.Flags().StringSliceVar(&printer.Columns, "columns", nil, "Comma-separated list of the columns to show in the table (e.g. name,phase,version,nodes)")
+	cmd.Flags().BoolVar(&includeStatus, "include-status", false, "Add the reason and message of the cluster status to the table")
+	cmd.Flags().BoolVar(&cached, "cached", false, "Use the clusters cached locally for the current context. They are refreshed if older than --cache-ttl, and used as is if the API can not be reached")
+	cmd.Flags().DurationVar(&cacheTTL, "cache-ttl", clustercache.DefaultTTL, "Maximum age of the cached clusters used with --cached")
+	cmd.Flags().DurationVar(&interval, "interval", 10*time.Second, "Polling interval used with --watch")
 	return cmd
 }
 
@@ -68,5 +91,67 @@ func listClusters(f *config.Factory, opts clustermodel.ListOptions) (*v1alpha1.C
 		}
 		clusters.Items[i].Status = cluster.Status
 	}
+	if opts.Provider == "" {
+		if err := clustercache.Save(clusters); err != nil {
+			klog.V(3).Infof("failed to cache the clusters. Reason: %v", err)
+		}
+	}
 	return clusters, nil
 }
+
+// cachedClusters returns the cached clusters of the current context if they are not older than ttl.
+// Otherwise, the clusters are listed from the API. Stale cached clusters are returned if the API
+// can not be reached, so that the clusters can still be viewed offline.
+func cachedClusters(f *config.Factory, opts clustermodel.ListOptions, ttl time.Duration) (*v1alpha1.ClusterInfoList, error) {
+	clusters, fetchedAt, cacheErr := clustercache.Load()
+	if cacheErr == nil && time.Since(fetchedAt) <= ttl {
+		return filterClusters(clusters, opts), nil
+	}
+
+	fresh, err := listClusters(f, clustermodel.ListOptions{})
+	if err == nil {
+		return filterClusters(fresh, opts), nil
+	}
+	if cacheErr != nil {
+		return nil, err
+	}
+	klog.Warningf("failed to list clusters, showing the clusters cached %s ago. Reason: %v", time.Since(fetchedAt).Round(time.Second), err)
+	return filterClusters(clusters, opts), nil
+}
+
+func filterClusters(clusters *v1alpha1.ClusterInfoList, opts clustermodel.ListOptions) *v1alpha1.ClusterInfoList {
+	if opts.Provider == "" {
+		return clusters
+	}
+	filtered := &v1alpha1.ClusterInfoList{}
+	for i := range clusters.Items {
+		if strings.EqualFold(string(clusters.Items[i].Spec.Provider), opts.Provider) {
+			filtered.Items = append(filtered.Items, clusters.Items[i])
+		}
+	}
+	return filtered
+}
+
+func watchClusters(f *config.Factory, opts clustermodel.ListOptions, interval time.Duration) error {
+	switch printer.OutputFormat {
+	case "", "table", "json", "ndjson":
+	default:
+		return fmt.Errorf("--watch supports only table, json and ndjson output")
+	}
+	done := f.Canceller()
+	watcher := printer.NewClusterWatcher()
+	for {
+		clusters, err := listClusters(f, opts)
+		if err != nil {
+			klog.Warningf("failed to list clusters. Reason: %v", err)
+		} else if err := watcher.Update(clusters.Items); err != nil {
+			return err
+		}
+
+		select {
+		case <-done:
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
@@ -0,0 +1,64 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Community License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Community-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"fmt"
+
+	"go.bytebuilders.dev/cli/pkg/completion"
+	"go.bytebuilders.dev/cli/pkg/config"
+	"go.bytebuilders.dev/cli/pkg/printer"
+
+	"github.com/spf13/cobra"
+)
+
+func newCmdProfiles(f *config.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "profiles",
+		Short:             "Manage cluster profiles used while importing clusters",
+		DisableAutoGenTag: true,
+	}
+	cmd.AddCommand(newCmdProfilesList(f))
+	return cmd
+}
+
+func newCmdProfilesList(f *config.Factory) *cobra.Command {
+	var catalogCluster string
+	cmd := &cobra.Command{
+		Use:               "list",
+		Short:             "List the available cluster profiles",
+		DisableAutoGenTag: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			kc, err := newCatalogClient(f, catalogCluster)
+			if err != nil {
+				return err
+			}
+			profiles, err := listClusterProfiles(kc)
+			if err != nil {
+				return err
+			}
+			if len(profiles) == 0 && printer.IsTableOutput() {
+				fmt.Println("No cluster profile found.")
+				return nil
+			}
+			return printer.PrintClusterProfiles(profiles)
+		},
+	}
+	cmd.Flags().StringVar(&catalogCluster, "catalog-cluster", "", "Name of the cluster to read the cluster profiles from (default is the first active cluster)")
+	_ = cmd.RegisterFlagCompletionFunc("catalog-cluster", completion.ClusterNames(f))
+	return cmd
+}
@@ -19,74 +19,144 @@ package cluster
 import (
 	"errors"
 	"fmt"
-	"sync"
 
+	"go.bytebuilders.dev/cli/pkg/completion"
 	"go.bytebuilders.dev/cli/pkg/config"
+	"go.bytebuilders.dev/cli/pkg/exitcode"
 	"go.bytebuilders.dev/cli/pkg/printer"
 	ace "go.bytebuilders.dev/client"
 	clustermodel "go.bytebuilders.dev/resource-model/apis/cluster"
 
-	"github.com/rs/xid"
 	"github.com/spf13/cobra"
 )
 
 func newCmdReconfigure(f *config.Factory) *cobra.Command {
 	opts := clustermodel.ReconfigureOptions{}
+	var featureSet map[string]string
+	var catalogCluster string
+	targets := clusterTargets{}
+	jf := jobFlags{}
 	cmd := &cobra.Command{
 		Use:               "reconfigure",
 		Short:             "Re-install cluster components to fix common issues",
+		Long:              "Re-install cluster components to fix common issues.\n\n" + bulkExitCodeUsage,
 		DisableAutoGenTag: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if !opts.Components.AllFeatures {
-				opts.Components.FeatureSets = defaultFeatureSet
+			names, err := targets.resolve(f)
+			if err != nil {
+				return err
+			}
+			if targets.isBulk() {
+				return reconfigureClusters(f, opts, featureSet, catalogCluster, names, targets.concurrency, jf)
+			}
+
+			opts.BasicInfo.Name = names[0]
+			err = setReconfigureComponents(f, &opts, featureSet, catalogCluster)
+			if err == nil {
+				err = reconfigureCluster(f, opts, jf)
 			}
-			err := reconfigureCluster(f, opts)
 			if err != nil {
 				if errors.Is(err, ace.ErrNotFound) {
-					fmt.Println("Provided cluster does not exist. Please provide a valid cluster name.")
-					return nil
+					return &exitcode.NotFoundError{Resource: "cluster", Name: opts.BasicInfo.Name}
 				}
 				return fmt.Errorf("failed to reconfigure cluster. Reason: %w", err)
 			}
 			return nil
 		},
 	}
-	cmd.Flags().StringVar(&opts.BasicInfo.Name, "name", "", "Name of the cluster to get")
+	targets.addFlags(f, cmd)
+	jf.addFlags(cmd)
 	cmd.Flags().BoolVar(&opts.Components.FluxCD, "install-fluxcd", true, "Specify whether to install FluxCD or not (default true).")
 	cmd.Flags().BoolVar(&opts.Components.AllFeatures, "all-features", false, "Install all features")
+	cmd.Flags().StringVar(&opts.Components.ClusterProfile, "cluster-profile", "", "Name of the cluster profile to use (see 'ace cluster profiles list')")
+	cmd.Flags().BoolVar(&opts.Components.SpokeComponents, "spoke-components", false, "Install the components required to register the cluster as a spoke of a hub cluster")
+	cmd.Flags().StringVar(&catalogCluster, "catalog-cluster", "", "Name of the cluster to validate the cluster profile against (default is the cluster being reconfigured)")
+	_ = cmd.RegisterFlagCompletionFunc("catalog-cluster", completion.ClusterNames(f))
+	cmd.Flags().StringToStringVar(&featureSet, "featureset", featureSet, "Features to add per feature set (e.g. opscenter-core=kube-ui-server). Prefix a feature with '-' to remove it")
+	cmd.MarkFlagsMutuallyExclusive("featureset", "all-features")
 	return cmd
 }
 
-func reconfigureCluster(f *config.Factory, opts clustermodel.ReconfigureOptions) error {
-	fmt.Println("Reconfiguring cluster......")
-	c, err := f.Client()
+// setReconfigureComponents validates the provided cluster profile against the catalog cluster (default is the
+// cluster being reconfigured) and applies the feature set changes on top of the features that are currently
+// enabled in the cluster.
+func setReconfigureComponents(f *config.Factory, opts *clustermodel.ReconfigureOptions, featureSet map[string]string, catalogCluster string) error {
+	if len(featureSet) == 0 && !opts.Components.AllFeatures {
+		opts.Components.FeatureSets = defaultFeatureSet
+	}
+	if len(featureSet) == 0 && opts.Components.ClusterProfile == "" {
+		return nil
+	}
+
+	kc, err := newCatalogClient(f, opts.BasicInfo.Name)
 	if err != nil {
 		return err
 	}
+	profileClient := kc
+	if catalogCluster != "" && catalogCluster != opts.BasicInfo.Name && opts.Components.ClusterProfile != "" {
+		profileClient, err = newCatalogClient(f, catalogCluster)
+		if err != nil {
+			return err
+		}
+	}
+	if err := validateClusterProfile(profileClient, opts.Components.ClusterProfile); err != nil {
+		return err
+	}
+	if len(featureSet) == 0 {
+		return nil
+	}
 
-	nc, err := c.NewNatsConnection("ace-cli")
+	catalog, err := loadFeatureCatalog(kc)
 	if err != nil {
 		return err
 	}
-	defer nc.Close() // nolint:errcheck
+	opts.Components.FeatureSets = applyFeatureSetChanges(catalog.enabledFeatureSets(), featureSet)
+	return catalog.validate(opts.Components.FeatureSets)
+}
 
-	responseID := xid.New().String()
-	wg := sync.WaitGroup{}
-	wg.Add(1)
-	done := f.Canceller()
-	go func() {
-		err := printer.PrintNATSJobSteps(&wg, nc, responseID, done)
-		if err != nil {
-			fmt.Println("Failed to log reconfigure steps. Reason: ", err)
-		}
-	}()
+func reconfigureCluster(f *config.Factory, opts clustermodel.ReconfigureOptions, jf jobFlags) error {
+	printer.Progressf("Reconfiguring cluster......\n")
+	c, err := f.Client()
+	if err != nil {
+		return err
+	}
 
-	_, err = c.ReconfigureCluster(opts, responseID)
+	nc, err := newNATSConn(c)
 	if err != nil {
-		close(done)
 		return err
 	}
-	wg.Wait()
+	defer nc.Close() // nolint:errcheck
 
-	return nil
+	return runNATSJob(f, nc, natsJob{
+		jobFlags: jf,
+		action:   "reconfigure",
+		cluster:  opts.BasicInfo.Name,
+		start: func(responseID string) error {
+			_, err := c.ReconfigureCluster(opts, responseID)
+			return err
+		},
+	})
+}
+
+// reconfigureClusters reconfigures multiple clusters in parallel. The feature set changes are applied
+// on top of the features that are currently enabled in each cluster.
+func reconfigureClusters(f *config.Factory, opts clustermodel.ReconfigureOptions, featureSet map[string]string, catalogCluster string, clusters []string, concurrency int, jf jobFlags) error {
+	return runBulkJob(f, "reconfigure", clusters, concurrency, func(c *ace.Client, nc *natsConn, cluster, prefix string) error {
+		clusterOpts := opts
+		clusterOpts.BasicInfo.Name = cluster
+		err := setReconfigureComponents(f, &clusterOpts, featureSet, catalogCluster)
+		if err != nil {
+			return err
+		}
+		return runNATSJob(f, nc, natsJob{
+			jobFlags: jf,
+			action:   "reconfigure",
+			cluster:  cluster,
+			prefix:   prefix,
+			start: func(responseID string) error {
+				_, err := c.ReconfigureCluster(clusterOpts, responseID)
+				return err
+			},
+		})
+	})
 }
@@ -17,76 +17,225 @@ limitations under the License.
 package cluster
 
 import (
+	"bufio"
 	"errors"
 	"fmt"
-	"sync"
+	"io"
+	"os"
+	"slices"
+	"strings"
 
+	"go.bytebuilders.dev/cli/pkg/cmds/utils"
 	"go.bytebuilders.dev/cli/pkg/config"
+	"go.bytebuilders.dev/cli/pkg/exitcode"
 	"go.bytebuilders.dev/cli/pkg/printer"
 	ace "go.bytebuilders.dev/client"
 	clustermodel "go.bytebuilders.dev/resource-model/apis/cluster"
 
-	"github.com/rs/xid"
+	"github.com/fatih/color"
+	"github.com/mattn/go-isatty"
 	"github.com/spf13/cobra"
 )
 
 func newCmdRemove(f *config.Factory) *cobra.Command {
 	opts := clustermodel.RemovalOptions{}
+	var kubeConfigPath string
+	var portableCredentials bool
+	var yes, forceProtected bool
+	targets := clusterTargets{}
+	jf := jobFlags{}
 	cmd := &cobra.Command{
 		Use:               "remove",
 		Short:             "Remove a cluster from ACE platform",
+		Long:              "Remove a cluster from ACE platform.\n\n" + bulkExitCodeUsage,
 		DisableAutoGenTag: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if portableCredentials && kubeConfigPath == "" {
+				return exitcode.Validationf("--portable-credentials requires --kubeconfig")
+			}
+			if portableCredentials && jf.noWait {
+				return exitcode.Validationf("--portable-credentials can not be used with --no-wait")
+			}
 			if !opts.Components.AllFeatures {
 				opts.Components.FeatureSets = defaultFeatureSet
 			}
-			err := removeCluster(f, opts)
+			names, err := targets.resolve(f)
+			if err != nil {
+				return err
+			}
+			if targets.isBulk() {
+				if portableCredentials {
+					return exitcode.Validationf("--portable-credentials can not be used when removing multiple clusters")
+				}
+				err = confirmBulkRemoval(names, opts, yes, forceProtected)
+				if err != nil {
+					return err
+				}
+				return removeClusters(f, opts, names, targets.concurrency, jf)
+			}
+
+			opts.Name = names[0]
+			err = confirmRemoval(f, opts, yes, forceProtected)
+			if err == nil {
+				err = removeCluster(f, opts, jf)
+			}
 			if err != nil {
 				if errors.Is(err, ace.ErrNotFound) {
-					fmt.Println("Cluster has been removed already.")
+					printer.Progressf("Cluster has been removed already.\n")
 					return nil
 				}
 				return fmt.Errorf("failed to remove cluster. Reason: %w", err)
 			}
+			if portableCredentials {
+				data, err := os.ReadFile(kubeConfigPath)
+				if err != nil {
+					return fmt.Errorf("failed to read Kubeconfig file. Reason: %w", err)
+				}
+				err = utils.CleanupPortableCredentials(data)
+				if err != nil {
+					return fmt.Errorf("failed to cleanup portable credentials. Reason: %w", err)
+				}
+				printer.Progressf("Successfully removed the ServiceAccount used by ACE\n")
+			}
 			return nil
 		},
 	}
-	cmd.Flags().StringVar(&opts.Name, "name", "", "Name of the cluster to get")
+	targets.addFlags(f, cmd)
+	jf.addFlags(cmd)
 	cmd.Flags().BoolVar(&opts.Components.FluxCD, "remove-fluxcd", true, "Specify whether to remove FluxCD or not (default true).")
 	cmd.Flags().BoolVar(&opts.Components.AllFeatures, "all-features", false, "Remove all features")
+	cmd.Flags().StringVar(&kubeConfigPath, "kubeconfig", "", "Path of the kubeconfig file used to cleanup the portable credentials")
+	cmd.Flags().BoolVar(&portableCredentials, "portable-credentials", false, "Remove the ServiceAccount created by --portable-credentials during import")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Remove the cluster without asking for confirmation")
+	cmd.Flags().BoolVar(&forceProtected, "force-protected", false, "Remove the cluster even if it is protected in the current context")
 	return cmd
 }
 
-func removeCluster(f *config.Factory, opts clustermodel.RemovalOptions) error {
-	fmt.Println("Removing cluster......")
-	c, err := f.Client()
+// confirmRemoval refuses to remove protected clusters unless forced and asks the user
+// for confirmation showing the cluster details, unless it has already been given.
+func confirmRemoval(f *config.Factory, opts clustermodel.RemovalOptions, yes, forceProtected bool) error {
+	protected, err := config.IsClusterProtected(opts.Name)
 	if err != nil {
 		return err
 	}
+	if protected && !forceProtected {
+		return fmt.Errorf("cluster %s is protected in the current context. Use --force-protected to remove it anyway", opts.Name)
+	}
+	if yes {
+		return nil
+	}
+	if !isatty.IsTerminal(os.Stdin.Fd()) {
+		return fmt.Errorf("refusing to remove cluster %s without confirmation. Use --yes to skip the confirmation", opts.Name)
+	}
 
-	nc, err := c.NewNatsConnection("ace-cli")
+	cluster, err := getCluster(f, clustermodel.GetOptions{Name: opts.Name})
 	if err != nil {
 		return err
 	}
-	defer nc.Close() // nolint:errcheck
+	printer.Progressf("The following cluster will be removed from ACE:\n")
+	printer.Progressf("  Name:         %s\n", cluster.Spec.Name)
+	printer.Progressf("  Display Name: %s\n", cluster.Spec.DisplayName)
+	printer.Progressf("  Provider:     %s\n", cluster.Spec.Provider)
+	printer.Progressf("  Node Count:   %d\n", cluster.Spec.NodeCount)
+	if opts.Components.FluxCD {
+		printer.Progressf("FluxCD will also be uninstalled from the cluster.\n")
+	}
+	if protected {
+		printer.Progressf("%s\n", color.YellowString("WARNING: the cluster is protected in the current context."))
+	}
+	return askForConfirmation()
+}
 
-	responseID := xid.New().String()
-	wg := sync.WaitGroup{}
-	wg.Add(1)
-	done := f.Canceller()
-	go func() {
-		err := printer.PrintNATSJobSteps(&wg, nc, responseID, done)
+// confirmBulkRemoval is the multi-cluster counterpart of confirmRemoval.
+func confirmBulkRemoval(clusters []string, opts clustermodel.RemovalOptions, yes, forceProtected bool) error {
+	var protected []string
+	for _, cluster := range clusters {
+		ok, err := config.IsClusterProtected(cluster)
 		if err != nil {
-			fmt.Println("Failed to log removal steps. Reason: ", err)
+			return err
+		}
+		if ok {
+			protected = append(protected, cluster)
+		}
+	}
+	if len(protected) > 0 && !forceProtected {
+		return fmt.Errorf("clusters %s are protected in the current context. Use --force-protected to remove them anyway", strings.Join(protected, ", "))
+	}
+	if yes {
+		return nil
+	}
+	if !isatty.IsTerminal(os.Stdin.Fd()) {
+		return fmt.Errorf("refusing to remove %d clusters without confirmation. Use --yes to skip the confirmation", len(clusters))
+	}
+
+	printer.Progressf("The following %d clusters will be removed from ACE:\n", len(clusters))
+	for _, cluster := range clusters {
+		if slices.Contains(protected, cluster) {
+			printer.Progressf("%s\n", color.YellowString("  %s (protected)", cluster))
+		} else {
+			printer.Progressf("  %s\n", cluster)
 		}
-	}()
+	}
+	if opts.Components.FluxCD {
+		printer.Progressf("FluxCD will also be uninstalled from the clusters.\n")
+	}
+	return askForConfirmation()
+}
+
+func askForConfirmation() error {
+	printer.Progressf("Do you want to continue? [y/N]: ")
+
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return nil
+	}
+	return exitcode.New(exitcode.Cancelled, fmt.Errorf("cluster removal aborted by user"))
+}
 
-	err = c.RemoveCluster(opts, responseID)
+func removeCluster(f *config.Factory, opts clustermodel.RemovalOptions, jf jobFlags) error {
+	printer.Progressf("Removing cluster......\n")
+	c, err := f.Client()
+	if err != nil {
+		return err
+	}
+
+	nc, err := newNATSConn(c)
 	if err != nil {
-		close(done)
 		return err
 	}
-	wg.Wait()
+	defer nc.Close() // nolint:errcheck
 
-	return nil
+	return runNATSJob(f, nc, natsJob{
+		jobFlags: jf,
+		action:   "removal",
+		cluster:  opts.Name,
+		start: func(responseID string) error {
+			return c.RemoveCluster(opts, responseID)
+		},
+	})
+}
+
+func removeClusters(f *config.Factory, opts clustermodel.RemovalOptions, clusters []string, concurrency int, jf jobFlags) error {
+	return runBulkJob(f, "removal", clusters, concurrency, func(c *ace.Client, nc *natsConn, cluster, prefix string) error {
+		clusterOpts := opts
+		clusterOpts.Name = cluster
+		err := runNATSJob(f, nc, natsJob{
+			jobFlags: jf,
+			action:   "removal",
+			cluster:  cluster,
+			prefix:   prefix,
+			start: func(responseID string) error {
+				return c.RemoveCluster(clusterOpts, responseID)
+			},
+		})
+		if errors.Is(err, ace.ErrNotFound) {
+			printer.Progressf("%sCluster has been removed already.\n", prefix)
+			return nil
+		}
+		return err
+	})
 }
@@ -0,0 +1,158 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Community License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Community-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"go.bytebuilders.dev/cli/pkg/completion"
+	"go.bytebuilders.dev/cli/pkg/config"
+	"go.bytebuilders.dev/cli/pkg/exitcode"
+	"go.bytebuilders.dev/cli/pkg/printer"
+	ace "go.bytebuilders.dev/client"
+	clustermodel "go.bytebuilders.dev/resource-model/apis/cluster"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	rsapi "kmodules.xyz/resource-metadata/apis/meta/v1alpha1"
+)
+
+const waitForDelete = "delete"
+
+func newCmdWait(f *config.Factory) *cobra.Command {
+	var name, condition string
+	var timeout time.Duration
+	var failOn []string
+	cmd := &cobra.Command{
+		Use:   "wait",
+		Short: "Wait for a cluster to reach a specific phase or to be removed",
+		Long: fmt.Sprintf(`Wait for a cluster to reach a specific phase or to be removed.
+
+The command exits with code %d on timeout, %d if the cluster does not exist while waiting for a phase
+and %d if the cluster reaches one of the terminal failure phases.`, exitcode.Timeout, exitcode.NotFound, exitcode.RemoteFailure),
+		Example: `  # Wait for an imported cluster to become active
+  ace cluster wait --name my-cluster --for phase=Active --timeout 15m
+
+  # Wait for a cluster to be removed
+  ace cluster wait --name my-cluster --for delete`,
+		DisableAutoGenTag: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if name == "" {
+				return exitcode.Validationf("please provide the cluster name using --name")
+			}
+			if condition == waitForDelete {
+				return waitForClusterRemoval(f, name, timeout)
+			}
+			phase, ok := strings.CutPrefix(condition, "phase=")
+			if !ok || phase == "" {
+				return exitcode.Validationf("invalid condition %q. Supported conditions are phase=<phase> and %s", condition, waitForDelete)
+			}
+			return waitForClusterPhase(f, name, rsapi.ClusterPhase(phase), failOn, timeout)
+		},
+	}
+	cmd.Flags().StringVar(&name, "name", "", "Name of the cluster to wait for")
+	_ = cmd.RegisterFlagCompletionFunc("name", completion.ClusterNames(f))
+	cmd.Flags().StringVar(&condition, "for", "phase=Active", "Condition to wait for. Either phase=<phase> or delete")
+	cmd.Flags().DurationVar(&timeout, "timeout", 15*time.Minute, "Maximum time to wait")
+	cmd.Flags().StringSliceVar(&failOn, "fail-on", []string{string(rsapi.ClusterPhaseLost)}, "Phases that are considered as terminal failure while waiting for a phase")
+	return cmd
+}
+
+func waitForClusterPhase(f *config.Factory, name string, phase rsapi.ClusterPhase, failOn []string, timeout time.Duration) error {
+	var lastPhase rsapi.ClusterPhase
+	return pollCluster(f, name, timeout, func(status *rsapi.ClusterStatusResponse) (bool, error) {
+		if status == nil {
+			return false, &exitcode.NotFoundError{Resource: "cluster", Name: name}
+		}
+		if status.Phase != lastPhase {
+			printer.Progressf("Cluster %s is in phase %s\n", name, status.Phase)
+			lastPhase = status.Phase
+		}
+		if status.Phase == phase {
+			return true, nil
+		}
+		if slices.Contains(failOn, string(status.Phase)) {
+			return false, exitcode.New(exitcode.RemoteFailure, fmt.Errorf("cluster %s reached terminal phase %s. Reason: %s %s", name, status.Phase, status.Reason, status.Message))
+		}
+		return false, nil
+	})
+}
+
+func waitForClusterRemoval(f *config.Factory, name string, timeout time.Duration) error {
+	return pollCluster(f, name, timeout, func(status *rsapi.ClusterStatusResponse) (bool, error) {
+		if status == nil {
+			printer.Progressf("Cluster %s has been removed\n", name)
+			return true, nil
+		}
+		return false, nil
+	})
+}
+
+// pollCluster calls GetCluster with an exponential backoff until the condition is met, the condition
+// returns an error or the timeout expires. The condition receives nil status if the cluster does not exist.
+// Transient API errors are retried.
+func pollCluster(f *config.Factory, name string, timeout time.Duration, condition func(status *rsapi.ClusterStatusResponse) (bool, error)) error {
+	c, err := f.Client()
+	if err != nil {
+		return err
+	}
+	done := f.Canceller()
+	deadline := time.Now().Add(timeout)
+	backoff := wait.Backoff{
+		Duration: 2 * time.Second,
+		Factor:   1.5,
+		Jitter:   0.1,
+		Steps:    math.MaxInt32,
+		Cap:      30 * time.Second,
+	}
+
+	for {
+		cluster, err := c.GetCluster(clustermodel.GetOptions{Name: name})
+		switch {
+		case err == nil:
+			ok, err := condition(&cluster.Status)
+			if err != nil || ok {
+				return err
+			}
+		case errors.Is(err, ace.ErrNotFound):
+			ok, err := condition(nil)
+			if err != nil || ok {
+				return err
+			}
+		case errors.Is(err, ace.ErrUnAuthorized), errors.Is(err, ace.ErrForbidden):
+			return err
+		default:
+			klog.Warningf("failed to get cluster %s. Reason: %v", name, err)
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return exitcode.New(exitcode.Timeout, fmt.Errorf("timed out after %s waiting for cluster %s", timeout, name))
+		}
+		select {
+		case <-done:
+			return exitcode.ErrCancelled
+		case <-time.After(min(backoff.Step(), remaining)):
+		}
+	}
+}
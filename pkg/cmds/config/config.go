@@ -30,6 +30,8 @@ func NewCmdConfig() *cobra.Command {
 	cmd.AddCommand(newCmdSet())
 	cmd.AddCommand(newCmdUse())
 	cmd.AddCommand(newCmdDelete())
+	cmd.AddCommand(newCmdProtect())
+	cmd.AddCommand(newCmdUnprotect())
 
 	return cmd
 }
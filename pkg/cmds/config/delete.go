@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 
+	"go.bytebuilders.dev/cli/pkg/completion"
 	"go.bytebuilders.dev/cli/pkg/config"
 
 	"github.com/spf13/cobra"
@@ -45,5 +46,6 @@ func newCmdDelete() *cobra.Command {
 		},
 	}
 	cmd.Flags().StringVar(&context, "context", "", "Name of the context to use")
+	_ = cmd.RegisterFlagCompletionFunc("context", completion.Contexts)
 	return cmd
 }
@@ -0,0 +1,73 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Community License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Community-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+
+	"go.bytebuilders.dev/cli/pkg/completion"
+	"go.bytebuilders.dev/cli/pkg/config"
+	"go.bytebuilders.dev/cli/pkg/exitcode"
+
+	"github.com/spf13/cobra"
+)
+
+func newCmdProtect() *cobra.Command {
+	var cluster string
+	cmd := &cobra.Command{
+		Use:               "protect",
+		Short:             "Protect a cluster of the current context from being removed",
+		DisableAutoGenTag: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cluster == "" {
+				return exitcode.Validationf("please provide the cluster name using --cluster")
+			}
+			err := config.ProtectCluster(cluster)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Cluster %s is now protected from removal\n", cluster)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&cluster, "cluster", "", "Name of the cluster to protect")
+	_ = cmd.RegisterFlagCompletionFunc("cluster", completion.ClusterNames(nil))
+	return cmd
+}
+
+func newCmdUnprotect() *cobra.Command {
+	var cluster string
+	cmd := &cobra.Command{
+		Use:               "unprotect",
+		Short:             "Remove the removal protection of a cluster of the current context",
+		DisableAutoGenTag: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cluster == "" {
+				return exitcode.Validationf("please provide the cluster name using --cluster")
+			}
+			err := config.UnprotectCluster(cluster)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Cluster %s is no longer protected from removal\n", cluster)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&cluster, "cluster", "", "Name of the cluster to unprotect")
+	_ = cmd.RegisterFlagCompletionFunc("cluster", completion.ClusterNames(nil))
+	return cmd
+}
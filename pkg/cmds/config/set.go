@@ -31,7 +31,7 @@ func newCmdSet() *cobra.Command {
 		Short:             "Create a new context or update existing context in CLI configuration",
 		DisableAutoGenTag: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			err := config.SetContext(ctx)
+			err := config.MergeContext(ctx)
 			if err != nil {
 				return err
 			}
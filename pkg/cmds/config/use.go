@@ -17,6 +17,7 @@ limitations under the License.
 package config
 
 import (
+	"go.bytebuilders.dev/cli/pkg/completion"
 	"go.bytebuilders.dev/cli/pkg/config"
 
 	"github.com/spf13/cobra"
@@ -33,5 +34,6 @@ func newCmdUse() *cobra.Command {
 		},
 	}
 	cmd.Flags().StringVar(&context, "context", "", "Name of the context to use")
+	_ = cmd.RegisterFlagCompletionFunc("context", completion.Contexts)
 	return cmd
 }
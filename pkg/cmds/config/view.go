@@ -17,12 +17,10 @@ limitations under the License.
 package config
 
 import (
-	"fmt"
-
 	"go.bytebuilders.dev/cli/pkg/config"
+	"go.bytebuilders.dev/cli/pkg/printer"
 
 	"github.com/spf13/cobra"
-	"sigs.k8s.io/yaml"
 )
 
 func newCmdView() *cobra.Command {
@@ -48,10 +46,5 @@ func viewConfig(showSensitiveData bool) error {
 		cfg.MaskSensitiveData()
 	}
 
-	data, err := yaml.Marshal(&cfg)
-	if err != nil {
-		return err
-	}
-	fmt.Println(string(data))
-	return nil
+	return printer.PrintObject(cfg, nil)
 }
@@ -78,6 +78,13 @@ func NewCmdGateway(f cmdutil.Factory) *cobra.Command {
 	}
 
 	cmd.Flags().StringVarP(&opt.db.resource, "db-type", "t", "mongodb", "Database type")
+	_ = cmd.RegisterFlagCompletionFunc("db-type", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		types, err := opt.dbTypes()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return types, cobra.ShellCompDirectiveNoFileComp
+	})
 	cmd.Flags().StringVarP(&opt.db.name, "name", "m", "mg-test", "Database name")
 	cmd.Flags().StringVarP(&opt.db.namespace, "namespace", "n", "demo", "Database namespace")
 	return cmd
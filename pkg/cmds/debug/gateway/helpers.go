@@ -24,6 +24,7 @@ import (
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/discovery"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/yaml"
@@ -106,6 +107,30 @@ func (g *gatewayOpts) populate(dc *discovery.DiscoveryClient, gv string) error {
 	return nil
 }
 
+// dbTypes returns the singular names of the KubeDB resources discovered in the cluster, which are
+// the values accepted by --db-type.
+func (g *gatewayOpts) dbTypes() ([]string, error) {
+	dc, err := discovery.NewDiscoveryClientForConfig(g.config)
+	if err != nil {
+		return nil, err
+	}
+	g.resMap = make(map[string]string)
+	// a KubeDB version may serve only one of the group versions
+	errV1 := g.populate(dc, "kubedb.com/v1")
+	errV1alpha2 := g.populate(dc, "kubedb.com/v1alpha2")
+	if errV1 != nil && errV1alpha2 != nil {
+		return nil, errV1
+	}
+
+	types := sets.New[string]()
+	for res, kind := range g.resMap {
+		if res == strings.ToLower(kind) {
+			types.Insert(res)
+		}
+	}
+	return sets.List(types), nil
+}
+
 func (g *gatewayOpts) getKindFromResource(res string) string {
 	kind, exists := g.resMap[res]
 	if !exists {
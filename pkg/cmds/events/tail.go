@@ -0,0 +1,122 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Community License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Community-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"fmt"
+	"os/signal"
+	"strings"
+	"time"
+
+	"go.bytebuilders.dev/cli/pkg/config"
+	"go.bytebuilders.dev/cli/pkg/exitcode"
+	"go.bytebuilders.dev/cli/pkg/printer"
+
+	"github.com/nats-io/nats.go"
+	"github.com/spf13/cobra"
+)
+
+const jobSubjectPrefix = "natjobs.resp."
+
+func newCmdTail(f *config.Factory) *cobra.Command {
+	var jsonOutput bool
+	var statuses []string
+	cmd := &cobra.Command{
+		Use:               "tail",
+		Short:             "Follow the import, reconfigure and remove jobs of the organization",
+		Long:              "Follow the import, reconfigure and remove jobs of the organization that the NATS credentials of the user are permitted to see, including the ones started by other users.",
+		DisableAutoGenTag: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			for _, s := range statuses {
+				switch strings.ToLower(s) {
+				case strings.ToLower(printer.JobStatusRunning), strings.ToLower(printer.JobStatusSuccess), strings.ToLower(printer.JobStatusFailed):
+				default:
+					return exitcode.Validationf("invalid status %q. Valid values are: %s, %s, %s", s, printer.JobStatusRunning, printer.JobStatusSuccess, printer.JobStatusFailed)
+				}
+			}
+			if jsonOutput {
+				printer.OutputFormat = "json"
+			}
+			return tailEvents(f, statuses)
+		},
+	}
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Print the step transitions as NDJSON events")
+	cmd.Flags().StringSliceVar(&statuses, "status", nil, fmt.Sprintf("Only show the jobs with these statuses (any of %s,%s,%s)", printer.JobStatusRunning, printer.JobStatusSuccess, printer.JobStatusFailed))
+	return cmd
+}
+
+func tailEvents(f *config.Factory, statuses []string) error {
+	c, err := f.Client()
+	if err != nil {
+		return err
+	}
+	nc, err := c.NewNatsConnection("ace-cli")
+	if err != nil {
+		return err
+	}
+	defer nc.Close() // nolint:errcheck
+
+	msgs := make(chan *nats.Msg, 100)
+	stopped := make(chan struct{})
+	defer close(stopped)
+	sub, err := nc.Subscribe(jobSubjectPrefix+"*", func(msg *nats.Msg) {
+		select {
+		case msgs <- msg:
+		case <-stopped:
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("failed to subscribe. Reason: %w", err)
+	}
+	defer sub.Unsubscribe() // nolint:errcheck
+	// keep the messages pending instead of dropping them while the view is being rendered
+	if err := sub.SetPendingLimits(-1, -1); err != nil {
+		return err
+	}
+	// permission violations are reported asynchronously
+	if err := nc.Flush(); err != nil {
+		return fmt.Errorf("failed to subscribe. Reason: %w", err)
+	}
+	if err := nc.LastError(); err != nil {
+		return fmt.Errorf("failed to subscribe to the jobs of the organization. Reason: %w", err)
+	}
+	printer.Progressf("Waiting for job events......\n")
+
+	view := printer.NewJobsView(statuses)
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+	done := f.Canceller()
+	defer signal.Stop(done)
+	closed := nc.StatusChanged(nats.CLOSED)
+	for {
+		select {
+		case <-done:
+			return nil
+		case <-closed:
+			return fmt.Errorf("connection to NATS has been closed. Reason: %v", nc.LastError())
+		case <-ticker.C:
+			if err := view.Refresh(); err != nil {
+				return err
+			}
+		case msg := <-msgs:
+			jobID := strings.TrimPrefix(msg.Subject, jobSubjectPrefix)
+			if err := view.Update(jobID, msg.Data); err != nil {
+				printer.Progressf("%v\n", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,147 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Community License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Community-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"errors"
+	"fmt"
+	"os/signal"
+	"time"
+
+	"go.bytebuilders.dev/cli/pkg/completion"
+	"go.bytebuilders.dev/cli/pkg/config"
+	"go.bytebuilders.dev/cli/pkg/exitcode"
+	"go.bytebuilders.dev/cli/pkg/journal"
+	"go.bytebuilders.dev/cli/pkg/printer"
+
+	"github.com/nats-io/nats.go"
+	"github.com/spf13/cobra"
+	"k8s.io/klog/v2"
+)
+
+// defaultInactivityTimeout stops attaching to a job that no longer reports any progress, e.g. the job
+// finished while its messages have expired from the stream.
+const defaultInactivityTimeout = 30 * time.Minute
+
+func newCmdAttach(f *config.Factory) *cobra.Command {
+	var inactivityTimeout time.Duration
+	cmd := &cobra.Command{
+		Use:               "attach <id>",
+		Short:             "Follow the progress of a job started with --no-wait",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.JobIDs,
+		DisableAutoGenTag: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return attachJob(f, args[0], inactivityTimeout)
+		},
+	}
+	cmd.Flags().DurationVar(&inactivityTimeout, "inactivity-timeout", defaultInactivityTimeout, "Stop waiting for the job if it doesn't report any progress within this duration (e.g. 10m). Zero means no timeout")
+	return cmd
+}
+
+func attachJob(f *config.Factory, responseID string, inactivityTimeout time.Duration) error {
+	// the progress is appended to the existing record of the job. Jobs that were not started
+	// from this machine are recorded as an attach operation.
+	operation, cluster := "attach", ""
+	if job, err := journal.Get(responseID); err == nil {
+		if job.FinishedAt != nil && job.Status != journal.StatusUnknown {
+			return finishedJobResult(job)
+		}
+		operation, cluster = job.Operation, job.Cluster
+	}
+
+	c, err := f.Client()
+	if err != nil {
+		return err
+	}
+	nc, err := c.NewNatsConnection("ace-cli")
+	if err != nil {
+		return err
+	}
+	defer func() { nc.Close() }()
+
+	jw, err := journal.Start(responseID, operation, cluster)
+	if err != nil {
+		klog.Warningf("failed to record job %s in the local journal. Reason: %v", responseID, err)
+	}
+
+	printer.Progressf("Attaching to job %s......\n", responseID)
+	done := f.Canceller()
+	defer signal.Stop(done)
+	err = printer.WatchNATSJobSteps(nc, responseID, printer.JobStepsOptions{
+		Replay:            true,
+		Cluster:           cluster,
+		OnMessage:         jw.RecordStep,
+		InactivityTimeout: inactivityTimeout,
+		Reconnect: func() (*nats.Conn, error) {
+			conn, err := c.NewNatsConnection("ace-cli")
+			if err != nil {
+				return nil, err
+			}
+			nc = conn
+			return nc, nil
+		},
+	}, done)
+	var failure *printer.JobFailedError
+	if errors.As(err, &failure) {
+		jw.Finish(journal.StatusFailed, failure)
+		return exitcode.New(exitcode.RemoteFailure, failure)
+	}
+	if errors.Is(err, printer.ErrJobInactive) {
+		jw.Close()
+		return exitcode.New(exitcode.Timeout, err)
+	}
+	if errors.Is(err, exitcode.ErrCancelled) {
+		jw.Close()
+		return err
+	}
+	if err != nil {
+		jw.Finish(journal.StatusUnknown, err)
+		return exitcode.New(exitcode.RemoteFailure, fmt.Errorf("failed to attach to job %s, its result is unknown. Reason: %w", responseID, err))
+	}
+	jw.Finish(journal.StatusSuccess, nil)
+	return nil
+}
+
+// finishedJobResult reports the result recorded in the journal of a job that has already finished,
+// as its progress can't be followed anymore.
+func finishedJobResult(job *journal.Job) error {
+	if printer.OutputFormat == "json" {
+		err := printer.PrintJobEvent(printer.JobEvent{
+			Type:      printer.JobEventResult,
+			JobID:     job.ID,
+			Cluster:   job.Cluster,
+			Status:    job.Status,
+			Message:   job.Error,
+			Timestamp: *job.FinishedAt,
+		})
+		if err != nil {
+			return err
+		}
+	} else {
+		printer.Progressf("Job %s has already finished at %s with status %s\n", job.ID, job.FinishedAt.Local().Format(time.RFC1123), job.Status)
+	}
+
+	switch job.Status {
+	case journal.StatusSuccess:
+		return nil
+	case journal.StatusFailed:
+		return exitcode.New(exitcode.RemoteFailure, fmt.Errorf("job %s failed: %s", job.ID, job.Error))
+	default:
+		return fmt.Errorf("job %s ended with status %s: %s", job.ID, job.Status, job.Error)
+	}
+}
@@ -0,0 +1,59 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Community License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Community-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"fmt"
+
+	"go.bytebuilders.dev/cli/pkg/completion"
+	"go.bytebuilders.dev/cli/pkg/journal"
+	"go.bytebuilders.dev/cli/pkg/printer"
+
+	"github.com/spf13/cobra"
+)
+
+func newCmdList() *cobra.Command {
+	var cluster string
+	cmd := &cobra.Command{
+		Use:               "list",
+		Short:             "List the jobs recorded in the local journal",
+		DisableAutoGenTag: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jobs, err := journal.List()
+			if err != nil {
+				return fmt.Errorf("failed to list jobs. Reason: %w", err)
+			}
+			if cluster != "" {
+				filtered := jobs[:0]
+				for i := range jobs {
+					if jobs[i].Cluster == cluster {
+						filtered = append(filtered, jobs[i])
+					}
+				}
+				jobs = filtered
+			}
+			if len(jobs) == 0 && printer.IsTableOutput() {
+				fmt.Println("No job found.")
+				return nil
+			}
+			return printer.PrintJobs(jobs)
+		},
+	}
+	cmd.Flags().StringVar(&cluster, "cluster", "", "List jobs only for this cluster")
+	_ = cmd.RegisterFlagCompletionFunc("cluster", completion.ClusterNames(nil))
+	return cmd
+}
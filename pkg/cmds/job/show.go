@@ -0,0 +1,69 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Community License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Community-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"go.bytebuilders.dev/cli/pkg/completion"
+	"go.bytebuilders.dev/cli/pkg/exitcode"
+	"go.bytebuilders.dev/cli/pkg/journal"
+	"go.bytebuilders.dev/cli/pkg/printer"
+
+	"github.com/spf13/cobra"
+)
+
+func newCmdShow() *cobra.Command {
+	var raw bool
+	cmd := &cobra.Command{
+		Use:               "show <id>",
+		Short:             "Show a job and its steps recorded in the local journal",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.JobIDs,
+		DisableAutoGenTag: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if raw {
+				path, err := journal.Path(args[0])
+				if err != nil {
+					return err
+				}
+				data, err := os.ReadFile(path)
+				if errors.Is(err, os.ErrNotExist) {
+					return &exitcode.NotFoundError{Resource: "job", Name: args[0]}
+				}
+				if err != nil {
+					return fmt.Errorf("failed to read journal of job %s. Reason: %w", args[0], err)
+				}
+				_, err = os.Stdout.Write(data)
+				return err
+			}
+
+			job, err := journal.Get(args[0])
+			if errors.Is(err, journal.ErrJobNotFound) {
+				return &exitcode.NotFoundError{Resource: "job", Name: args[0]}
+			}
+			if err != nil {
+				return err
+			}
+			return printer.PrintJob(job)
+		},
+	}
+	cmd.Flags().BoolVar(&raw, "raw", false, "Print the journal file as is (e.g. to attach it to a support ticket)")
+	return cmd
+}
@@ -19,6 +19,8 @@ package cmds
 import (
 	"os"
 	"os/signal"
+	"slices"
+	"strings"
 	"syscall"
 
 	"go.bytebuilders.dev/cli/pkg/cmds/auth"
@@ -26,8 +28,13 @@ import (
 	"go.bytebuilders.dev/cli/pkg/cmds/cluster"
 	cmdconfig "go.bytebuilders.dev/cli/pkg/cmds/config"
 	"go.bytebuilders.dev/cli/pkg/cmds/debug"
+	"go.bytebuilders.dev/cli/pkg/cmds/events"
 	"go.bytebuilders.dev/cli/pkg/cmds/installer"
+	"go.bytebuilders.dev/cli/pkg/cmds/job"
+	"go.bytebuilders.dev/cli/pkg/completion"
 	"go.bytebuilders.dev/cli/pkg/config"
+	"go.bytebuilders.dev/cli/pkg/exitcode"
+	"go.bytebuilders.dev/cli/pkg/printer"
 	ace "go.bytebuilders.dev/client"
 
 	"github.com/spf13/cobra"
@@ -38,18 +45,41 @@ func NewRootCmd() *cobra.Command {
 	rootCmd := &cobra.Command{
 		Use:               "ace",
 		Short:             `CLI to interact with ACE platform`,
-		Long:              `A cli to interact with ACE (AppsCode Container Engine) platform`,
+		Long:              "A cli to interact with ACE (AppsCode Container Engine) platform\n\n" + exitcode.Usage(),
 		DisableAutoGenTag: true,
+		// errors are printed by main in the format requested with --error-format
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if !slices.Contains(printer.ErrorFormats, printer.ErrorFormat) {
+				return exitcode.Validationf("invalid error format %q. Valid values are: %s", printer.ErrorFormat, strings.Join(printer.ErrorFormats, ", "))
+			}
+			return nil
+		},
 	}
+	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return exitcode.Validationf("%w\nSee '%s --help' for usage.", err, cmd.CommandPath())
+	})
 	rootCmd.PersistentFlags().StringVar(&config.CurrentContext, "context", "", "Use this as current context instead of one from configuration file")
 	rootCmd.PersistentFlags().StringVar(&config.Organization, "org", "", "Use this organization for instead of auto-detecting current one")
+	rootCmd.PersistentFlags().StringVarP(&printer.OutputFormat, "output", "o", "", printer.OutputFormatUsage)
+	rootCmd.PersistentFlags().StringVar(&printer.ErrorFormat, "error-format", "text", printer.ErrorFormatUsage)
+	rootCmd.PersistentFlags().BoolVar(&printer.NoHeaders, "no-headers", false, "Don't print the headers of the tables")
 
 	f := &config.Factory{
 		Client:    aceClient,
 		Canceller: canceller,
 	}
+	_ = rootCmd.RegisterFlagCompletionFunc("context", completion.Contexts)
+	_ = rootCmd.RegisterFlagCompletionFunc("org", completion.Organizations(f))
+	_ = rootCmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions(printer.OutputFormats, cobra.ShellCompDirectiveNoFileComp))
+	_ = rootCmd.RegisterFlagCompletionFunc("error-format", cobra.FixedCompletions(printer.ErrorFormats, cobra.ShellCompDirectiveNoFileComp))
 	rootCmd.AddCommand(cmdconfig.NewCmdConfig())
 	rootCmd.AddCommand(cluster.NewCmdCluster(f))
+	rootCmd.AddCommand(cluster.NewCmdApply(f))
+	rootCmd.AddCommand(cluster.NewCmdDiff(f))
+	rootCmd.AddCommand(job.NewCmdJob(f))
+	rootCmd.AddCommand(events.NewCmdEvents(f))
 	rootCmd.AddCommand(auth.NewCmdAuth())
 
 	rootCmd.AddCommand(cloud_swap.NewCmdCloudSwap())
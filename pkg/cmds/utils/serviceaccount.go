@@ -0,0 +1,243 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Community License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Community-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const (
+	portableCredentialName      = "ace-platform"
+	portableCredentialNamespace = metav1.NamespaceSystem
+	portableCredentialSecret    = "ace-platform-token"
+	portableCredentialRole      = "cluster-admin"
+	portableTokenTimeout        = time.Minute
+)
+
+const (
+	managedByLabel = "app.kubernetes.io/managed-by"
+	managedByValue = "ace-cli"
+)
+
+var portableCredentialLabels = map[string]string{
+	managedByLabel: managedByValue,
+}
+
+// GeneratePortableKubeConfig uses the credentials of the provided kubeconfig to create a dedicated
+// ServiceAccount with cluster-admin access in the target cluster. It returns a self-contained
+// kubeconfig that authenticates with the ServiceAccount token, so that it can be used outside of
+// the local machine (i.e. without exec plugins like `aws eks get-token` or `gke-gcloud-auth-plugin`).
+// Existing objects are reused only if they are labeled as managed by the ace cli.
+func GeneratePortableKubeConfig(kubeConfig []byte) ([]byte, error) {
+	cfg, err := clientcmd.RESTConfigFromKubeConfig(kubeConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build rest config from kubeconfig. Reason: %w", err)
+	}
+	if err := rest.LoadTLSFiles(cfg); err != nil {
+		return nil, err
+	}
+	kc, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ensurePortableServiceAccount(kc); err != nil {
+		return nil, err
+	}
+	if err := ensurePortableClusterRoleBinding(kc); err != nil {
+		return nil, err
+	}
+	secret, err := ensurePortableTokenSecret(kc)
+	if err != nil {
+		return nil, err
+	}
+
+	caData := secret.Data[corev1.ServiceAccountRootCAKey]
+	if len(caData) == 0 {
+		caData = cfg.CAData
+	}
+	kubeCfg := RESTConfigToKubeconfig(&rest.Config{
+		Host:        cfg.Host,
+		BearerToken: string(secret.Data[corev1.ServiceAccountTokenKey]),
+		TLSClientConfig: rest.TLSClientConfig{
+			CAData:   caData,
+			Insecure: cfg.Insecure,
+		},
+	})
+	return clientcmd.Write(*kubeCfg)
+}
+
+// CleanupPortableCredentials removes the ServiceAccount, token Secret and ClusterRoleBinding
+// created by GeneratePortableKubeConfig. Missing objects are ignored and objects that are not
+// labeled as managed by the ace cli are left untouched.
+func CleanupPortableCredentials(kubeConfig []byte) error {
+	cfg, err := clientcmd.RESTConfigFromKubeConfig(kubeConfig)
+	if err != nil {
+		return fmt.Errorf("failed to build rest config from kubeconfig. Reason: %w", err)
+	}
+	kc, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	crbs := kc.RbacV1().ClusterRoleBindings()
+	crb, err := crbs.Get(context.TODO(), portableCredentialName, metav1.GetOptions{})
+	if err = deleteManaged("ClusterRoleBinding "+portableCredentialName, crb, err, crbs.Delete); err != nil {
+		return err
+	}
+	secrets := kc.CoreV1().Secrets(portableCredentialNamespace)
+	secret, err := secrets.Get(context.TODO(), portableCredentialSecret, metav1.GetOptions{})
+	if err = deleteManaged("Secret "+portableCredentialNamespace+"/"+portableCredentialSecret, secret, err, secrets.Delete); err != nil {
+		return err
+	}
+	sas := kc.CoreV1().ServiceAccounts(portableCredentialNamespace)
+	sa, err := sas.Get(context.TODO(), portableCredentialName, metav1.GetOptions{})
+	return deleteManaged("ServiceAccount "+portableCredentialNamespace+"/"+portableCredentialName, sa, err, sas.Delete)
+}
+
+// deleteManaged deletes the object returned by a Get call, provided that it is managed by the ace cli.
+// The deletion is conditioned on the UID, so that an object recreated in the meantime is not removed.
+func deleteManaged(desc string, obj metav1.Object, getErr error, del func(ctx context.Context, name string, opts metav1.DeleteOptions) error) error {
+	if kerr.IsNotFound(getErr) {
+		return nil
+	}
+	if getErr != nil {
+		return fmt.Errorf("failed to get %s. Reason: %w", desc, getErr)
+	}
+	if err := checkManaged(desc, obj); err != nil {
+		return err
+	}
+	uid := obj.GetUID()
+	err := del(context.TODO(), obj.GetName(), metav1.DeleteOptions{Preconditions: &metav1.Preconditions{UID: &uid}})
+	if err != nil && !kerr.IsNotFound(err) {
+		return fmt.Errorf("failed to delete %s. Reason: %w", desc, err)
+	}
+	return nil
+}
+
+// checkManaged refuses to touch objects that were not created by the ace cli.
+func checkManaged(desc string, obj metav1.Object) error {
+	if obj.GetLabels()[managedByLabel] != managedByValue {
+		return fmt.Errorf("refusing to modify %s. Reason: it is not labeled with %s=%s", desc, managedByLabel, managedByValue)
+	}
+	return nil
+}
+
+func ensurePortableServiceAccount(kc kubernetes.Interface) error {
+	sa := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      portableCredentialName,
+			Namespace: portableCredentialNamespace,
+			Labels:    portableCredentialLabels,
+		},
+	}
+	_, err := kc.CoreV1().ServiceAccounts(portableCredentialNamespace).Create(context.TODO(), sa, metav1.CreateOptions{})
+	if kerr.IsAlreadyExists(err) {
+		existing, err := kc.CoreV1().ServiceAccounts(portableCredentialNamespace).Get(context.TODO(), portableCredentialName, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to get ServiceAccount %s/%s. Reason: %w", portableCredentialNamespace, portableCredentialName, err)
+		}
+		return checkManaged("ServiceAccount "+portableCredentialNamespace+"/"+portableCredentialName, existing)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create ServiceAccount %s/%s. Reason: %w", portableCredentialNamespace, portableCredentialName, err)
+	}
+	return nil
+}
+
+func ensurePortableClusterRoleBinding(kc kubernetes.Interface) error {
+	crb := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   portableCredentialName,
+			Labels: portableCredentialLabels,
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     portableCredentialRole,
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      rbacv1.ServiceAccountKind,
+				Name:      portableCredentialName,
+				Namespace: portableCredentialNamespace,
+			},
+		},
+	}
+	_, err := kc.RbacV1().ClusterRoleBindings().Create(context.TODO(), crb, metav1.CreateOptions{})
+	if kerr.IsAlreadyExists(err) {
+		existing, err := kc.RbacV1().ClusterRoleBindings().Get(context.TODO(), portableCredentialName, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to get ClusterRoleBinding %s. Reason: %w", portableCredentialName, err)
+		}
+		return checkManaged("ClusterRoleBinding "+portableCredentialName, existing)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create ClusterRoleBinding %s. Reason: %w", portableCredentialName, err)
+	}
+	return nil
+}
+
+func ensurePortableTokenSecret(kc kubernetes.Interface) (*corev1.Secret, error) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      portableCredentialSecret,
+			Namespace: portableCredentialNamespace,
+			Labels:    portableCredentialLabels,
+			Annotations: map[string]string{
+				corev1.ServiceAccountNameKey: portableCredentialName,
+			},
+		},
+		Type: corev1.SecretTypeServiceAccountToken,
+	}
+	_, err := kc.CoreV1().Secrets(portableCredentialNamespace).Create(context.TODO(), secret, metav1.CreateOptions{})
+	if kerr.IsAlreadyExists(err) {
+		existing, err := kc.CoreV1().Secrets(portableCredentialNamespace).Get(context.TODO(), portableCredentialSecret, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get Secret %s/%s. Reason: %w", portableCredentialNamespace, portableCredentialSecret, err)
+		}
+		if err := checkManaged("Secret "+portableCredentialNamespace+"/"+portableCredentialSecret, existing); err != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to create Secret %s/%s. Reason: %w", portableCredentialNamespace, portableCredentialSecret, err)
+	}
+
+	// the token controller populates the token asynchronously
+	err = wait.PollUntilContextTimeout(context.TODO(), time.Second, portableTokenTimeout, true, func(ctx context.Context) (bool, error) {
+		secret, err = kc.CoreV1().Secrets(portableCredentialNamespace).Get(ctx, portableCredentialSecret, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		return len(secret.Data[corev1.ServiceAccountTokenKey]) > 0, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token for ServiceAccount %s/%s. Reason: %w", portableCredentialNamespace, portableCredentialName, err)
+	}
+	return secret, nil
+}
@@ -0,0 +1,160 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Community License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Community-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package completion provides the dynamic shell completions of the flags and arguments of the CLI.
+// The completions never fail loudly: if the values can not be found, nothing is completed.
+package completion
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"go.bytebuilders.dev/cli/pkg/clustercache"
+	"go.bytebuilders.dev/cli/pkg/config"
+	"go.bytebuilders.dev/cli/pkg/journal"
+	clustermodel "go.bytebuilders.dev/resource-model/apis/cluster"
+	"go.bytebuilders.dev/resource-model/apis/cluster/v1alpha1"
+
+	"github.com/spf13/cobra"
+	kmapi "kmodules.xyz/client-go/api/v1"
+)
+
+// Providers are the hosting providers of the clusters that can be imported.
+var Providers = []kmapi.HostingProvider{
+	kmapi.HostingProviderAKS,
+	kmapi.HostingProviderAkamai,
+	kmapi.HostingProviderDigitalOcean,
+	kmapi.HostingProviderEKS,
+	kmapi.HostingProviderExoscale,
+	kmapi.HostingProviderGKE,
+	kmapi.HostingProviderGeneric,
+	kmapi.HostingProviderLinode,
+	kmapi.HostingProviderPacket,
+	kmapi.HostingProviderRancher,
+	kmapi.HostingProviderScaleway,
+	kmapi.HostingProviderVultr,
+}
+
+// Contexts completes the names of the saved contexts.
+func Contexts(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var names []string
+	for _, ctx := range cfg.Contexts {
+		names = append(names, ctx.Name)
+	}
+	return filter(names, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// ProviderNames completes the hosting providers.
+func ProviderNames(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	names := make([]string, 0, len(Providers))
+	for _, p := range Providers {
+		names = append(names, string(p))
+	}
+	return filter(names, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// ClusterNames completes the names of the clusters of the current context. The local cluster cache
+// is used if it is fresh, so that the completion is instant. Otherwise, the clusters are listed
+// from the API if a factory is provided.
+func ClusterNames(f *config.Factory) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		clusters := listClusters(f)
+		if clusters == nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		var completions []cobra.Completion
+		for i := range clusters.Items {
+			name := clusters.Items[i].Spec.Name
+			if !strings.HasPrefix(name, toComplete) {
+				continue
+			}
+			completions = append(completions, cobra.CompletionWithDesc(name, string(clusters.Items[i].Status.Phase)))
+		}
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+func listClusters(f *config.Factory) *v1alpha1.ClusterInfoList {
+	clusters, fetchedAt, err := clustercache.LoadPartial()
+	if err == nil && (f == nil || time.Since(fetchedAt) <= clustercache.DefaultTTL) {
+		return clusters
+	}
+	if f == nil {
+		return nil
+	}
+	c, err := f.Client()
+	if err != nil {
+		return clusters
+	}
+	// the status of the clusters is not needed, so the clusters are not fetched one by one
+	fresh, err := c.ListClusters(clustermodel.ListOptions{})
+	if err != nil {
+		return clusters
+	}
+	// cache the names for the next completions, the cache file is chosen by the current context and org
+	_ = clustercache.SavePartial(fresh)
+	return fresh
+}
+
+// Organizations completes the organizations known for the current context: the organization of
+// the signed in user and the organizations whose clusters have been cached.
+func Organizations(f *config.Factory) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		orgs, _ := clustercache.Organizations()
+		if c, err := f.Client(); err == nil {
+			if user, err := c.GetCurrentUser(); err == nil {
+				orgs = append(orgs, user.UserName)
+			}
+		}
+		return filter(orgs, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// JobIDs completes the IDs of the jobs recorded in the local journal, latest first.
+func JobIDs(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	jobs, err := journal.List()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var completions []cobra.Completion
+	for _, job := range jobs {
+		if strings.HasPrefix(job.ID, toComplete) {
+			completions = append(completions, cobra.CompletionWithDesc(job.ID, job.Operation+" "+job.Cluster+" ("+job.Status+")"))
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveKeepOrder
+}
+
+// filter returns the unique values that start with prefix, sorted.
+func filter(values []string, prefix string) []cobra.Completion {
+	sort.Strings(values)
+	var completions []cobra.Completion
+	for i, v := range values {
+		if v == "" || (i > 0 && values[i-1] == v) || !strings.HasPrefix(v, prefix) {
+			continue
+		}
+		completions = append(completions, v)
+	}
+	return completions
+}
@@ -22,6 +22,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"slices"
 
 	"sigs.k8s.io/yaml"
 )
@@ -49,6 +50,8 @@ type Context struct {
 	Endpoint string        `json:"endpoint,omitempty"`
 	Token    string        `json:"token,omitempty"`
 	Cookies  []http.Cookie `json:"cookies,omitempty"`
+	// ProtectedClusters are the clusters that the CLI refuses to remove unless forced
+	ProtectedClusters []string `json:"protectedClusters,omitempty"`
 }
 
 func ReadConfig() (Config, error) {
@@ -104,6 +107,50 @@ func SetContext(ctx Context) error {
 	return cfg.save()
 }
 
+// MergeContext creates the context or updates the fields of the existing one that are set in ctx.
+// The other fields, e.g. the protected clusters, are kept. The context becomes the current one.
+func MergeContext(ctx Context) error {
+	cfg, err := ReadConfig()
+	if err != nil {
+		return err
+	}
+	contextExist, idx := cfg.isContextExist(ctx.Name)
+	if contextExist {
+		existing := cfg.Contexts[idx]
+		if ctx.Endpoint != "" {
+			existing.Endpoint = ctx.Endpoint
+		}
+		if ctx.Token != "" {
+			existing.Token = ctx.Token
+		}
+		if ctx.Cookies != nil {
+			existing.Cookies = ctx.Cookies
+		}
+		if ctx.ProtectedClusters != nil {
+			existing.ProtectedClusters = ctx.ProtectedClusters
+		}
+		cfg.Contexts[idx] = existing
+	} else {
+		cfg.Contexts = append(cfg.Contexts, ctx)
+	}
+	cfg.CurrentContext = ctx.Name
+	return cfg.save()
+}
+
+// updateContext updates the context in place without changing the current context.
+func updateContext(name string, update func(ctx *Context)) error {
+	cfg, err := ReadConfig()
+	if err != nil {
+		return err
+	}
+	contextExist, idx := cfg.isContextExist(name)
+	if !contextExist {
+		return fmt.Errorf("no data found for context: %s", name)
+	}
+	update(&cfg.Contexts[idx])
+	return cfg.save()
+}
+
 func DeleteContext(ctx string) error {
 	cfg, err := ReadConfig()
 	if err != nil {
@@ -122,6 +169,24 @@ func DeleteContext(ctx string) error {
 	return cfg.save()
 }
 
+// GetCurrentContextName returns the name of the context in use.
+func GetCurrentContextName() (string, error) {
+	cfg, err := ReadConfig()
+	if err != nil {
+		return "", err
+	}
+	return cfg.getCurrentContext(), nil
+}
+
+// Dir returns the directory that holds the config file. Other local state of the CLI is kept there.
+func Dir() (string, error) {
+	configFile, err := getConfigFilepath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Dir(configFile), nil
+}
+
 func SetCurrentContext(ctx string) error {
 	cfg, err := ReadConfig()
 	if err != nil {
@@ -135,6 +200,38 @@ func SetCurrentContext(ctx string) error {
 	return cfg.save()
 }
 
+func IsClusterProtected(cluster string) (bool, error) {
+	ctx, err := GetContext()
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(ctx.ProtectedClusters, cluster), nil
+}
+
+func ProtectCluster(cluster string) error {
+	name, err := GetCurrentContextName()
+	if err != nil {
+		return err
+	}
+	return updateContext(name, func(ctx *Context) {
+		if !slices.Contains(ctx.ProtectedClusters, cluster) {
+			ctx.ProtectedClusters = append(ctx.ProtectedClusters, cluster)
+		}
+	})
+}
+
+func UnprotectCluster(cluster string) error {
+	name, err := GetCurrentContextName()
+	if err != nil {
+		return err
+	}
+	return updateContext(name, func(ctx *Context) {
+		ctx.ProtectedClusters = slices.DeleteFunc(ctx.ProtectedClusters, func(c string) bool {
+			return c == cluster
+		})
+	})
+}
+
 func (cfg *Config) MaskSensitiveData() {
 	for i := range cfg.Contexts {
 		cfg.Contexts[i].Cookies = nil
@@ -0,0 +1,135 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Community License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Community-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exitcode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	ace "go.bytebuilders.dev/client"
+)
+
+// Exit codes returned by the CLI. Any error that doesn't carry a specific code exits with GeneralError.
+const (
+	Success       = 0
+	GeneralError  = 1
+	Validation    = 2
+	Auth          = 3
+	NotFound      = 4
+	RemoteFailure = 5
+	Timeout       = 6
+	Cancelled     = 130
+)
+
+var reasons = []struct {
+	code   int
+	reason string
+	desc   string
+}{
+	{Success, "Success", "the command completed successfully"},
+	{GeneralError, "GeneralError", "an unexpected error occurred"},
+	{Validation, "Validation", "invalid flags, arguments or input files"},
+	{Auth, "Auth", "the credentials are missing, invalid or lack the required permissions"},
+	{NotFound, "NotFound", "the requested resource does not exist"},
+	{RemoteFailure, "RemoteFailure", "a remote job or the cluster reported a failure"},
+	{Timeout, "Timeout", "the command timed out waiting for an operation"},
+	{Cancelled, "Cancelled", "the command was interrupted or aborted by the user"},
+}
+
+// ErrCancelled is returned when the user terminates a running command.
+var ErrCancelled = errors.New("command terminated by user")
+
+// Error wraps an error with the exit code the CLI should terminate with.
+type Error struct {
+	Code int
+	Err  error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func New(code int, err error) error {
+	return &Error{Code: code, Err: err}
+}
+
+// Validationf returns an error that exits with the Validation code.
+func Validationf(format string, a ...any) error {
+	return New(Validation, fmt.Errorf(format, a...))
+}
+
+// NotFoundError reports that a resource does not exist. It matches ace.ErrNotFound with errors.Is.
+type NotFoundError struct {
+	Resource string
+	Name     string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%s %s does not exist", e.Resource, e.Name)
+}
+
+func (e *NotFoundError) Unwrap() error {
+	return ace.ErrNotFound
+}
+
+// Code returns the exit code for the provided error.
+func Code(err error) int {
+	if err == nil {
+		return Success
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Code
+	}
+	switch {
+	case errors.Is(err, ErrCancelled), errors.Is(err, context.Canceled):
+		return Cancelled
+	case errors.Is(err, context.DeadlineExceeded):
+		return Timeout
+	case errors.Is(err, ace.ErrUnAuthorized), errors.Is(err, ace.ErrForbidden):
+		return Auth
+	case errors.Is(err, ace.ErrNotFound):
+		return NotFound
+	}
+	return GeneralError
+}
+
+// Reason returns the name of the provided exit code.
+func Reason(code int) string {
+	for _, r := range reasons {
+		if r.code == code {
+			return r.reason
+		}
+	}
+	return reasons[GeneralError].reason
+}
+
+// Usage describes the exit codes for the help text of the commands.
+func Usage() string {
+	var sb strings.Builder
+	sb.WriteString("Exit codes:\n")
+	for _, r := range reasons {
+		fmt.Fprintf(&sb, "  %-4d%-15s%s\n", r.code, r.reason, r.desc)
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
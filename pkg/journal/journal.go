@@ -0,0 +1,319 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Community License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Community-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package journal keeps a local history of the jobs started by the CLI. Every job is stored as a
+// JSONL file under the config directory holding the job metadata, the raw NATS step messages and
+// the final result.
+package journal
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"go.bytebuilders.dev/cli/pkg/config"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	StatusRunning  = "Running"
+	StatusDetached = "Detached"
+	StatusSuccess  = "Success"
+	StatusFailed   = "Failed"
+	StatusError    = "Error"
+	// StatusUnknown is recorded when the cli lost track of a job before it finished
+	StatusUnknown = "Unknown"
+)
+
+const (
+	recordStart = "start"
+	recordStep  = "step"
+	recordEnd   = "end"
+)
+
+var ErrJobNotFound = errors.New("job not found in the local journal")
+
+// Job is a job read back from the journal.
+type Job struct {
+	ID         string     `json:"id"`
+	Operation  string     `json:"operation"`
+	Cluster    string     `json:"cluster,omitempty"`
+	Context    string     `json:"context,omitempty"`
+	Status     string     `json:"status"`
+	Error      string     `json:"error,omitempty"`
+	StartedAt  time.Time  `json:"startedAt"`
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+	Steps      []Step     `json:"steps,omitempty"`
+}
+
+// Step is a step message of a job along with the time it was received.
+type Step struct {
+	Time    time.Time `json:"time"`
+	ID      string    `json:"id"`
+	Step    string    `json:"step,omitempty"`
+	Status  string    `json:"status"`
+	Message string    `json:"message,omitempty"`
+}
+
+// record is a single line of the journal file of a job.
+type record struct {
+	Type      string          `json:"type"`
+	Time      time.Time       `json:"time"`
+	ID        string          `json:"id,omitempty"`
+	Operation string          `json:"operation,omitempty"`
+	Cluster   string          `json:"cluster,omitempty"`
+	Context   string          `json:"context,omitempty"`
+	Data      json.RawMessage `json:"data,omitempty"`
+	Status    string          `json:"status,omitempty"`
+	Error     string          `json:"error,omitempty"`
+}
+
+// Writer appends the records of a job to its journal file. All the methods are no-op on a nil Writer,
+// so that a failure to open the journal never prevents the job from running.
+type Writer struct {
+	f    *os.File
+	seen map[string]bool
+}
+
+// Dir returns the directory that holds the journal files.
+func Dir() (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "jobs"), nil
+}
+
+// Path returns the path of the journal file of a job.
+func Path(id string) (string, error) {
+	if id == "" || strings.ContainsAny(id, `/\.`) {
+		return "", fmt.Errorf("invalid job id %q", id)
+	}
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, id+".jsonl"), nil
+}
+
+// Start creates the journal of a new job. If the journal of the job already exists (e.g. the job
+// was started with --no-wait and is now being attached to), new records are appended to it and
+// the step messages that were already recorded are skipped.
+func Start(id, operation, cluster string) (*Writer, error) {
+	path, err := Path(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+
+	w := &Writer{seen: map[string]bool{}}
+	existing, err := readRecords(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	for _, r := range existing {
+		if r.Type == recordStep {
+			w.seen[string(r.Data)] = true
+		}
+	}
+
+	w.f, err = os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) == 0 {
+		if err := w.writeStart(id, operation, cluster); err != nil {
+			// don't leave a journal without a start record behind, it can't be read back
+			_ = w.f.Close()
+			_ = os.Remove(path)
+			return nil, err
+		}
+	}
+	return w, nil
+}
+
+func (w *Writer) writeStart(id, operation, cluster string) error {
+	ctx, err := config.GetCurrentContextName()
+	if err != nil {
+		return err
+	}
+	return w.write(record{
+		Type:      recordStart,
+		ID:        id,
+		Operation: operation,
+		Cluster:   cluster,
+		Context:   ctx,
+	})
+}
+
+// RecordStep records a raw step message of the job.
+func (w *Writer) RecordStep(data []byte) {
+	if w == nil || w.seen[string(data)] {
+		return
+	}
+	w.seen[string(data)] = true
+	_ = w.write(record{Type: recordStep, Data: json.RawMessage(data)})
+}
+
+// Finish records the final status of the job and closes the journal.
+func (w *Writer) Finish(status string, jobErr error) {
+	if w == nil {
+		return
+	}
+	r := record{Type: recordEnd, Status: status}
+	if jobErr != nil {
+		r.Error = jobErr.Error()
+	}
+	_ = w.write(r)
+	w.Close()
+}
+
+// Close closes the journal without recording a result, i.e. the job is considered to be still running.
+func (w *Writer) Close() {
+	if w == nil {
+		return
+	}
+	_ = w.f.Close()
+}
+
+func (w *Writer) write(r record) error {
+	r.Time = time.Now()
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	_, err = w.f.Write(append(data, '\n'))
+	return err
+}
+
+// Get reads the journal of a job including its steps.
+func Get(id string) (*Job, error) {
+	path, err := Path(id)
+	if err != nil {
+		return nil, err
+	}
+	records, err := readRecords(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrJobNotFound, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toJob(records)
+}
+
+// List returns all the jobs of the journal, latest first. The steps of the jobs are not included.
+// The journals that can't be read are skipped with a warning.
+func List() ([]Job, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	files, err := filepath.Glob(filepath.Join(dir, "*.jsonl"))
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]Job, 0, len(files))
+	for _, file := range files {
+		records, err := readRecords(file)
+		if err != nil {
+			klog.Warningf("skipping journal %s. Reason: %v", file, err)
+			continue
+		}
+		job, err := toJob(records)
+		if err != nil {
+			klog.Warningf("skipping journal %s. Reason: %v", file, err)
+			continue
+		}
+		job.Steps = nil
+		jobs = append(jobs, *job)
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].StartedAt.After(jobs[j].StartedAt)
+	})
+	return jobs, nil
+}
+
+func readRecords(path string) ([]record, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close() // nolint:errcheck
+
+	var records []record
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var r record
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			return nil, err
+		}
+		records = append(records, r)
+	}
+	return records, scanner.Err()
+}
+
+func toJob(records []record) (*Job, error) {
+	if len(records) == 0 || records[0].Type != recordStart {
+		return nil, fmt.Errorf("journal does not start with a %s record", recordStart)
+	}
+	job := &Job{
+		ID:        records[0].ID,
+		Operation: records[0].Operation,
+		Cluster:   records[0].Cluster,
+		Context:   records[0].Context,
+		Status:    StatusRunning,
+		StartedAt: records[0].Time,
+	}
+
+	// only the first message of a step carries its name
+	names := map[string]string{}
+	for _, r := range records[1:] {
+		switch r.Type {
+		case recordStep:
+			step := Step{}
+			if err := json.Unmarshal(r.Data, &step); err != nil {
+				return nil, err
+			}
+			if step.Step != "" {
+				names[step.ID] = step.Step
+			}
+			step.Step = names[step.ID]
+			step.Time = r.Time
+			job.Steps = append(job.Steps, step)
+		case recordEnd:
+			job.Status = r.Status
+			job.Error = r.Error
+			finishedAt := r.Time
+			job.FinishedAt = &finishedAt
+		}
+	}
+	return job, nil
+}
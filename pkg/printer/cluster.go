@@ -17,104 +17,99 @@ limitations under the License.
 package printer
 
 import (
-	"encoding/json"
-	"fmt"
-	"os"
-	"text/tabwriter"
+	"strconv"
+	"time"
 
 	"go.bytebuilders.dev/resource-model/apis/cluster/v1alpha1"
 
-	"sigs.k8s.io/yaml"
+	"github.com/fatih/color"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+	rsapi "kmodules.xyz/resource-metadata/apis/meta/v1alpha1"
 )
 
-var OutputFormat string
-
-type clusterPrinter interface {
-	printCluster(cluster *v1alpha1.ClusterInfo) error
-	printClusterList(clusters []v1alpha1.ClusterInfo) error
-}
-
-func newPrinter() clusterPrinter {
-	var printer clusterPrinter
-	switch OutputFormat {
-	case "json":
-		printer = &jsonPrinter{}
-	case "yaml":
-		printer = &yamlPrinter{}
-	default:
-		printer = &tablePrinter{}
-	}
-	return printer
-}
-
 func PrintCluster(cluster *v1alpha1.ClusterInfo) error {
-	printer := newPrinter()
-	return printer.printCluster(cluster)
-}
-
-func PrintClusterList(clusters []v1alpha1.ClusterInfo) error {
-	printer := newPrinter()
-	return printer.printClusterList(clusters)
-}
-
-type tablePrinter struct{}
-
-func (p *tablePrinter) printCluster(cluster *v1alpha1.ClusterInfo) error {
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 5, ' ', 0)
-	_, _ = fmt.Fprintln(w, "NAME\tDISPLAY_NAME\tPROVIDER\tPHASE")
-	_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", cluster.Spec.Name, cluster.Spec.DisplayName, cluster.Spec.Provider, cluster.Status.Phase)
-	return w.Flush()
+	return PrintObject(cluster, clusterTable([]v1alpha1.ClusterInfo{*cluster}, false))
 }
 
-func (p *tablePrinter) printClusterList(clusters []v1alpha1.ClusterInfo) error {
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 5, ' ', 0)
-	_, _ = fmt.Fprintln(w, "NAME\tDISPLAY_NAME\tPROVIDER\tPHASE")
-	for i := range clusters {
-		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", clusters[i].Spec.Name, clusters[i].Spec.DisplayName, clusters[i].Spec.Provider, clusters[i].Status.Phase)
+// PrintClusterList prints the clusters as a ClusterInfoList, so that json and yaml output can be parsed
+// as a single document. Use -o ndjson to print one cluster per line instead. If includeStatus is true,
+// the reason and message of the cluster status are added to the table.
+func PrintClusterList(clusters []v1alpha1.ClusterInfo, includeStatus bool) error {
+	list := &v1alpha1.ClusterInfoList{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1alpha1.SchemeGroupVersion.String(),
+			Kind:       v1alpha1.ResourceKindClusterInfo + "List",
+		},
+		Items: clusters,
 	}
-	return w.Flush()
+	return PrintObject(list, clusterTable(clusters, includeStatus))
 }
 
-type jsonPrinter struct{}
-
-func (p *jsonPrinter) printCluster(cluster *v1alpha1.ClusterInfo) error {
-	data, err := json.MarshalIndent(cluster, "", " ")
-	if err != nil {
-		return err
+func clusterTable(clusters []v1alpha1.ClusterInfo, includeStatus bool) *Table {
+	table := &Table{
+		Columns: []Column{
+			{Name: "NAME"},
+			{Name: "DISPLAY_NAME"},
+			{Name: "PROVIDER"},
+			{Name: "PHASE", Color: phaseColor},
+			{Name: "VERSION", Wide: true},
+			{Name: "NODES", Wide: true},
+			{Name: "LOCATION", Wide: true},
+			{Name: "PROJECT", Wide: true},
+			{Name: "ENDPOINT", Wide: true},
+			{Name: "OWNER", Wide: true},
+			{Name: "AGE", Wide: true},
+		},
+	}
+	if includeStatus {
+		table.Columns = append(table.Columns, Column{Name: "REASON"}, Column{Name: "MESSAGE"})
 	}
-	fmt.Println(string(data))
-	return nil
-}
-
-func (p *jsonPrinter) printClusterList(clusters []v1alpha1.ClusterInfo) error {
 	for i := range clusters {
-		data, err := json.MarshalIndent(clusters[i], "", " ")
-		if err != nil {
-			return err
+		spec := clusters[i].Spec
+		row := Row{
+			Cells: []string{
+				spec.Name,
+				spec.DisplayName,
+				string(spec.Provider),
+				string(clusters[i].Status.Phase),
+				spec.KubernetesVersion,
+				strconv.Itoa(int(spec.NodeCount)),
+				spec.Location,
+				spec.Project,
+				spec.Endpoint,
+				spec.OwnerName,
+				clusterAge(spec),
+			},
+			Name:   spec.Name,
+			Object: clusters[i],
 		}
-		fmt.Println(string(data))
+		if includeStatus {
+			row.Cells = append(row.Cells, string(clusters[i].Status.Reason), clusters[i].Status.Message)
+		}
+		table.Rows = append(table.Rows, row)
 	}
-	return nil
+	return table
 }
 
-type yamlPrinter struct{}
-
-func (p *yamlPrinter) printCluster(cluster *v1alpha1.ClusterInfo) error {
-	data, err := yaml.Marshal(cluster)
-	if err != nil {
-		return err
+func clusterAge(spec v1alpha1.ClusterInfoSpec) string {
+	if spec.Age != "" {
+		return spec.Age
+	}
+	if spec.CreatedAt == 0 {
+		return "<unknown>"
 	}
-	fmt.Println(string(data))
-	return nil
+	return duration.HumanDuration(time.Since(time.Unix(spec.CreatedAt, 0)))
 }
 
-func (p *yamlPrinter) printClusterList(clusters []v1alpha1.ClusterInfo) error {
-	for i := range clusters {
-		data, err := yaml.Marshal(clusters[i])
-		if err != nil {
-			return err
-		}
-		fmt.Println(string(data))
+func phaseColor(phase string) color.Attribute {
+	switch rsapi.ClusterPhase(phase) {
+	case rsapi.ClusterPhaseActive:
+		return color.FgGreen
+	case rsapi.ClusterPhaseNotReady, rsapi.ClusterPhaseRegistered, rsapi.ClusterPhaseNotImported:
+		return color.FgYellow
+	case rsapi.ClusterPhaseInactive, rsapi.ClusterPhaseNotConnected, rsapi.ClusterPhaseLost:
+		return color.FgRed
 	}
-	return nil
+	return color.Reset
 }
@@ -0,0 +1,171 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Community License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Community-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"go.bytebuilders.dev/cli/pkg/journal"
+	"go.bytebuilders.dev/resource-model/apis/cluster/v1alpha1"
+
+	rsapi "kmodules.xyz/resource-metadata/apis/meta/v1alpha1"
+)
+
+// PrintClusterDescription prints a detailed, kubectl-describe style view of the cluster along with
+// the recent jobs recorded for it in the local journal and hints to fix the common failure phases.
+// With any output format other than table, the cluster is printed as it is.
+func PrintClusterDescription(cluster *v1alpha1.ClusterInfo, jobs []journal.Job) error {
+	if OutputFormat != "" && OutputFormat != "table" {
+		return PrintCluster(cluster)
+	}
+
+	spec := cluster.Spec
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(w, "Name:\t%s\n", spec.Name)
+	_, _ = fmt.Fprintf(w, "Display Name:\t%s\n", spec.DisplayName)
+	_, _ = fmt.Fprintf(w, "UID:\t%s\n", spec.UID)
+	_, _ = fmt.Fprintf(w, "Provider:\t%s\n", orNone(string(spec.Provider)))
+	_, _ = fmt.Fprintf(w, "Owner:\t%s\n", orNone(spec.OwnerName))
+	_, _ = fmt.Fprintf(w, "Endpoint:\t%s\n", orNone(spec.Endpoint))
+	_, _ = fmt.Fprintf(w, "Location:\t%s\n", orNone(spec.Location))
+	_, _ = fmt.Fprintf(w, "Project:\t%s\n", orNone(spec.Project))
+	_, _ = fmt.Fprintf(w, "Kubernetes Version:\t%s\n", orNone(spec.KubernetesVersion))
+	_, _ = fmt.Fprintf(w, "Nodes:\t%d\n", spec.NodeCount)
+	if spec.CreatedAt != 0 {
+		_, _ = fmt.Fprintf(w, "Created:\t%s (%s ago)\n", time.Unix(spec.CreatedAt, 0).Local().Format(time.DateTime), clusterAge(spec))
+	}
+	if len(cluster.Labels) > 0 {
+		labels := make([]string, 0, len(cluster.Labels))
+		for k, v := range cluster.Labels {
+			labels = append(labels, k+"="+v)
+		}
+		sort.Strings(labels)
+		_, _ = fmt.Fprintf(w, "Labels:\t%s\n", strings.Join(labels, ","))
+	}
+
+	status := cluster.Status
+	_, _ = fmt.Fprintln(w, "Status:")
+	_, _ = fmt.Fprintf(w, "  Phase:\t%s\n", orNone(string(status.Phase)))
+	_, _ = fmt.Fprintf(w, "  Reason:\t%s\n", orNone(string(status.Reason)))
+	_, _ = fmt.Fprintf(w, "  Message:\t%s\n", orNone(status.Message))
+	_, _ = fmt.Fprintf(w, "  Cluster Managers:\t%s\n", orNone(strings.Join(status.ClusterManagers, ", ")))
+	if capi := status.ClusterAPI; capi != nil {
+		_, _ = fmt.Fprintln(w, "Cluster API:")
+		_, _ = fmt.Fprintf(w, "  Provider:\t%s\n", orNone(string(capi.Provider)))
+		_, _ = fmt.Fprintf(w, "  Namespace:\t%s\n", orNone(capi.Namespace))
+		_, _ = fmt.Fprintf(w, "  Cluster Name:\t%s\n", orNone(capi.ClusterName))
+	}
+	if md := status.ClusterMetadata; md != nil {
+		_, _ = fmt.Fprintln(w, "Cluster Metadata:")
+		_, _ = fmt.Fprintf(w, "  Mode:\t%s\n", orNone(string(md.Mode)))
+		_, _ = fmt.Fprintf(w, "  API Endpoint:\t%s\n", orNone(md.APIEndpoint))
+		_, _ = fmt.Fprintf(w, "  Owner Type:\t%s\n", orNone(md.OwnerType))
+		_, _ = fmt.Fprintf(w, "  Manager ID:\t%s\n", orNone(md.ManagerID))
+		_, _ = fmt.Fprintf(w, "  Hub Cluster ID:\t%s\n", orNone(md.HubClusterID))
+		_, _ = fmt.Fprintf(w, "  Cloud Service Auth Mode:\t%s\n", orNone(md.CloudServiceAuthMode))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if len(jobs) > 0 {
+		fmt.Println("\nRecent Jobs:")
+		w = tabwriter.NewWriter(os.Stdout, 0, 0, 5, ' ', 0)
+		_, _ = fmt.Fprintln(w, "  STARTED\tID\tOPERATION\tSTATUS")
+		for _, job := range jobs {
+			_, _ = fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", job.StartedAt.Local().Format(time.DateTime), job.ID, job.Operation, job.Status)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+
+	if hints := clusterHints(spec.Name, status); len(hints) > 0 {
+		fmt.Println("\nHints:")
+		printHints(os.Stdout, hints)
+	}
+	return nil
+}
+
+// PrintClusterHints prints the hints to fix the cluster, if any, after the table output of a command.
+func PrintClusterHints(cluster *v1alpha1.ClusterInfo) {
+	if !IsTableOutput() {
+		return
+	}
+	hints := clusterHints(cluster.Spec.Name, cluster.Status)
+	if len(hints) == 0 {
+		return
+	}
+	fmt.Println("\nHints:")
+	printHints(os.Stdout, hints)
+	fmt.Printf("  - Run 'ace cluster describe --name %s' for more details.\n", cluster.Spec.Name)
+}
+
+// clusterHints returns the actions that can fix the cluster based on its phase and the reason
+// behind it. No hint is returned for active clusters.
+func clusterHints(name string, status rsapi.ClusterStatusResponse) []string {
+	var hints []string
+	switch status.Reason {
+	case rsapi.ClusterPhaseReasonAuthIssue:
+		hints = append(hints, fmt.Sprintf("The credentials used by the platform were rejected by the cluster. Update them with 'ace cluster connect --name %s --kubeconfig <file>'.", name))
+	case rsapi.ClusterPhaseReasonMissingComponent:
+		hints = append(hints, fmt.Sprintf("Some of the components of the platform are missing in the cluster. Re-install them with 'ace cluster reconfigure --name %s'.", name))
+	case rsapi.ClusterPhaseReasonClusterNotFound:
+		hints = append(hints, fmt.Sprintf("The cluster does not exist anymore at the provider. Remove it with 'ace cluster remove --name %s'.", name))
+	}
+	if len(hints) > 0 {
+		return hints
+	}
+
+	switch status.Phase {
+	case rsapi.ClusterPhaseNotConnected:
+		hints = append(hints,
+			"The platform cannot reach the cluster. Make sure the cluster is running and its API server is reachable from the platform.",
+			fmt.Sprintf("If the credentials of the cluster have changed, update them with 'ace cluster connect --name %s --kubeconfig <file>'.", name),
+		)
+	case rsapi.ClusterPhaseInactive:
+		hints = append(hints, fmt.Sprintf("The components of the platform are not running in the cluster. Re-install them with 'ace cluster reconfigure --name %s'.", name))
+	case rsapi.ClusterPhaseNotReady:
+		hints = append(hints, fmt.Sprintf("The components of the platform are still being installed. Wait for them with 'ace cluster wait --name %s --for phase=Active'.", name))
+	case rsapi.ClusterPhaseRegistered:
+		hints = append(hints, fmt.Sprintf("The cluster is registered, but the components of the platform are not installed yet. Install them with 'ace cluster reconfigure --name %s'.", name))
+	case rsapi.ClusterPhaseNotImported:
+		hints = append(hints, "The cluster is not imported yet. Import it with 'ace cluster import'.")
+	case rsapi.ClusterPhaseLost:
+		hints = append(hints, fmt.Sprintf("The platform has lost the cluster. Remove it with 'ace cluster remove --name %s' and import it again.", name))
+	}
+	return hints
+}
+
+func printHints(out io.Writer, hints []string) {
+	for _, hint := range hints {
+		_, _ = fmt.Fprintf(out, "  - %s\n", hint)
+	}
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "<none>"
+	}
+	return s
+}
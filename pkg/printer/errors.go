@@ -0,0 +1,61 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Community License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Community-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"go.bytebuilders.dev/cli/pkg/exitcode"
+
+	"k8s.io/klog/v2"
+)
+
+// ErrorFormat is the format requested with --error-format
+var ErrorFormat string
+
+// ErrorFormats are the formats the errors can be printed in.
+var ErrorFormats = []string{"text", "json"}
+
+// ErrorFormatUsage describes the supported error formats.
+const ErrorFormatUsage = "Format of the error printed on failure. One of: (text, json). The json format prints the error, exit code and reason to stderr."
+
+type errorOutput struct {
+	Error  string `json:"error"`
+	Code   int    `json:"code"`
+	Reason string `json:"reason"`
+}
+
+// PrintError prints the error that terminated the command to stderr in the requested format.
+func PrintError(err error) {
+	if ErrorFormat != "json" {
+		klog.Errorln(err)
+		return
+	}
+	code := exitcode.Code(err)
+	data, jerr := json.Marshal(errorOutput{
+		Error:  err.Error(),
+		Code:   code,
+		Reason: exitcode.Reason(code),
+	})
+	if jerr != nil {
+		klog.Errorln(err)
+		return
+	}
+	_, _ = fmt.Fprintln(os.Stderr, string(data))
+}
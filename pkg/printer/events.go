@@ -0,0 +1,217 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Community License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Community-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/mattn/go-isatty"
+)
+
+// Status of a job as seen by JobsView.
+const (
+	JobStatusRunning = "Running"
+	JobStatusSuccess = stepSucceeded
+	JobStatusFailed  = stepFailed
+	// the following statuses are only used in the result events of the jobs that didn't report a result
+	JobStatusError     = "Error"
+	JobStatusUnknown   = "Unknown"
+	JobStatusCancelled = "Cancelled"
+)
+
+// maxLiveJobs is the number of most recently updated jobs shown in the live view.
+const maxLiveJobs = 50
+
+// Jobs are forgotten once they have been completed for completedJobTTL, or haven't been updated for staleJobTTL,
+// so that tailing for a long time doesn't keep every job seen in memory.
+const (
+	completedJobTTL = 10 * time.Minute
+	staleJobTTL     = time.Hour
+)
+
+type jobState struct {
+	id        string
+	tracker   *stepTracker
+	parentID  string
+	status    string
+	current   *jobStep
+	updatedAt time.Time
+}
+
+// JobsView correlates the step messages of multiple jobs by their job ID. On terminals, the jobs are
+// rendered as a table that is refreshed in place. Otherwise, a line is printed for every step
+// transition, or a JobEvent with json output.
+type JobsView struct {
+	jobs     map[string]*jobState
+	statuses []string
+	live     bool
+	dirty    bool
+	// evictedAt is the last time the old jobs were evicted
+	evictedAt time.Time
+}
+
+// NewJobsView returns a view that only shows the jobs with one of the given statuses.
+// All the jobs are shown if no status is given.
+func NewJobsView(statuses []string) *JobsView {
+	return &JobsView{
+		jobs:     map[string]*jobState{},
+		statuses: statuses,
+		live:     OutputFormat != "json" && isatty.IsTerminal(os.Stdout.Fd()),
+	}
+}
+
+// Update records a step message of a job.
+func (v *JobsView) Update(jobID string, data []byte) error {
+	msg := natsMessage{}
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return fmt.Errorf("failed to parse message of job %s. Reason: %w", jobID, err)
+	}
+
+	now := time.Now()
+	job, ok := v.jobs[jobID]
+	if !ok {
+		job = &jobState{id: jobID, tracker: newStepTracker(), status: JobStatusRunning}
+		v.jobs[jobID] = job
+	}
+	// only a step without a parent is the root of the job. The job stays running until its root is seen,
+	// as the job may have started before tailing.
+	if job.parentID == "" && msg.Parent == "" {
+		job.parentID = msg.ID
+	}
+	step := job.tracker.update(msg, now)
+	job.current = step
+	job.updatedAt = now
+	if job.parentID != "" && msg.ID == job.parentID && step.completed() {
+		job.status = step.status
+	}
+	v.evict(now)
+	if !isStepStartedOrCompleted(msg.Status) || !v.matches(job) {
+		return nil
+	}
+
+	if v.live {
+		v.dirty = true
+		return nil
+	}
+	if OutputFormat == "json" {
+		e := JobEvent{
+			Type:      JobEventStep,
+			JobID:     jobID,
+			StepID:    step.id,
+			Step:      step.name,
+			Status:    step.status,
+			Message:   step.message,
+			Timestamp: now,
+		}
+		if step.completed() {
+			e.Duration = seconds(step.finishedAt.Sub(step.startedAt))
+		}
+		if err := PrintJobEvent(e); err != nil {
+			return err
+		}
+		if job.status != JobStatusRunning && job.parentID != "" && msg.ID == job.parentID {
+			return PrintJobEvent(JobEvent{
+				Type:      JobEventResult,
+				JobID:     jobID,
+				Status:    job.status,
+				Timestamp: now,
+				Duration:  e.Duration,
+			})
+		}
+		return nil
+	}
+	line := fmt.Sprintf("%s  %s  %s %s%s", now.Format(time.TimeOnly), jobID, strings.ToUpper(step.status),
+		strings.Repeat("  ", step.depth), step.name)
+	if step.message != "" {
+		line += ": " + step.message
+	}
+	fmt.Println(line)
+	return nil
+}
+
+// evict forgets the jobs that have been completed or inactive for long. It runs at most once a minute.
+func (v *JobsView) evict(now time.Time) {
+	if now.Sub(v.evictedAt) < time.Minute {
+		return
+	}
+	v.evictedAt = now
+	for id, job := range v.jobs {
+		idle := now.Sub(job.updatedAt)
+		if (job.status != JobStatusRunning && idle > completedJobTTL) || idle > staleJobTTL {
+			delete(v.jobs, id)
+		}
+	}
+}
+
+// Refresh redraws the live view if it has changed since the last refresh.
+func (v *JobsView) Refresh() error {
+	if !v.live || !v.dirty {
+		return nil
+	}
+	v.dirty = false
+
+	jobs := make([]*jobState, 0, len(v.jobs))
+	for _, job := range v.jobs {
+		if v.matches(job) {
+			jobs = append(jobs, job)
+		}
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].updatedAt.After(jobs[j].updatedAt)
+	})
+	if len(jobs) > maxLiveJobs {
+		jobs = jobs[:maxLiveJobs]
+	}
+
+	now := time.Now()
+	fmt.Print(clearScreen)
+	fmt.Printf("Last updated: %s\n\n", now.Format(time.RFC1123))
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 5, ' ', 0)
+	_, _ = fmt.Fprintln(w, "JOB\tSTATUS\tCURRENT_STEP\tSTEPS\tSTARTED\tDURATION")
+	for _, job := range jobs {
+		completed := 0
+		for _, s := range job.tracker.steps {
+			if s.completed() {
+				completed++
+			}
+		}
+		root := job.tracker.steps[0]
+		if s, ok := job.tracker.byID[job.parentID]; ok {
+			root = s
+		}
+		current := job.current.name
+		if job.current.status == stepFailed && job.current.message != "" {
+			current += ": " + job.current.message
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%d/%d\t%s\t%s\n", job.id, job.status, current, completed, len(job.tracker.steps),
+			root.startedAt.Local().Format(time.TimeOnly), root.duration(now))
+	}
+	return w.Flush()
+}
+
+func (v *JobsView) matches(job *jobState) bool {
+	return len(v.statuses) == 0 || slices.ContainsFunc(v.statuses, func(s string) bool {
+		return strings.EqualFold(s, job.status)
+	})
+}
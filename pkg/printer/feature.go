@@ -0,0 +1,65 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Community License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Community-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"strings"
+)
+
+type FeatureSetInfo struct {
+	Name             string   `json:"name"`
+	Title            string   `json:"title,omitempty"`
+	RequiredFeatures []string `json:"requiredFeatures,omitempty"`
+	Features         []string `json:"features,omitempty"`
+}
+
+type FeatureStatus struct {
+	FeatureSet   string `json:"featureSet"`
+	Feature      string `json:"feature"`
+	Namespace    string `json:"namespace,omitempty"`
+	ChartVersion string `json:"chartVersion,omitempty"`
+	Ready        string `json:"ready"`
+	Message      string `json:"message,omitempty"`
+}
+
+func PrintFeatureSets(featureSets []FeatureSetInfo) error {
+	table := &Table{
+		Columns: []Column{{Name: "FEATURE_SET"}, {Name: "TITLE"}, {Name: "REQUIRED_FEATURES"}, {Name: "FEATURES"}},
+	}
+	for i := range featureSets {
+		table.Rows = append(table.Rows, Row{
+			Cells:  []string{featureSets[i].Name, featureSets[i].Title, strings.Join(featureSets[i].RequiredFeatures, ","), strings.Join(featureSets[i].Features, ",")},
+			Name:   featureSets[i].Name,
+			Object: featureSets[i],
+		})
+	}
+	return PrintObject(featureSets, table)
+}
+
+func PrintFeatureStatuses(statuses []FeatureStatus) error {
+	table := &Table{
+		Columns: []Column{{Name: "FEATURE_SET"}, {Name: "FEATURE"}, {Name: "NAMESPACE", Wide: true}, {Name: "VERSION"}, {Name: "READY"}, {Name: "MESSAGE"}},
+	}
+	for i := range statuses {
+		table.Rows = append(table.Rows, Row{
+			Cells:  []string{statuses[i].FeatureSet, statuses[i].Feature, statuses[i].Namespace, statuses[i].ChartVersion, statuses[i].Ready, statuses[i].Message},
+			Name:   statuses[i].Feature,
+			Object: statuses[i],
+		})
+	}
+	return PrintObject(statuses, table)
+}
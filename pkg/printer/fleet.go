@@ -0,0 +1,86 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Community License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Community-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// Actions of a fleet plan.
+const (
+	FleetActionImport      = "import"
+	FleetActionReconfigure = "reconfigure"
+	FleetActionRemove      = "remove"
+	FleetActionUnmanaged   = "unmanaged"
+	FleetActionError       = "error"
+)
+
+// FleetChange is a change that is required to bring a cluster to the state declared in the fleet file.
+type FleetChange struct {
+	Cluster string `json:"cluster"`
+	Action  string `json:"action"`
+	// Details lists the drifted features (e.g. "+ opscenter-core/kube-ui-server") or the reason
+	// behind the action.
+	Details []string `json:"details,omitempty"`
+}
+
+// PrintFleetPlan prints the changes of the plan like a diff. Clusters that are in sync are not listed.
+func PrintFleetPlan(changes []FleetChange) error {
+	if !IsTableOutput() {
+		table := &Table{
+			Columns: []Column{{Name: "CLUSTER"}, {Name: "ACTION"}, {Name: "DETAILS"}},
+		}
+		for _, c := range changes {
+			table.Rows = append(table.Rows, Row{
+				Cells:  []string{c.Cluster, c.Action, strings.Join(c.Details, "; ")},
+				Name:   c.Cluster,
+				Object: c,
+			})
+		}
+		return PrintObject(changes, table)
+	}
+
+	counts := map[string]int{}
+	for _, c := range changes {
+		counts[c.Action]++
+		switch c.Action {
+		case FleetActionImport:
+			color.Green("+ %s (import)", c.Cluster)
+		case FleetActionReconfigure:
+			color.Yellow("~ %s (reconfigure)", c.Cluster)
+		case FleetActionRemove:
+			color.Red("- %s (remove)", c.Cluster)
+		case FleetActionError:
+			color.Red("! %s", c.Cluster)
+		default:
+			fmt.Printf("  %s (%s)\n", c.Cluster, c.Action)
+		}
+		for _, d := range c.Details {
+			fmt.Printf("    %s\n", d)
+		}
+	}
+	if counts[FleetActionImport]+counts[FleetActionReconfigure]+counts[FleetActionRemove] == 0 && counts[FleetActionError] == 0 {
+		fmt.Println("No changes. The clusters match the fleet file.")
+		return nil
+	}
+	fmt.Printf("\nPlan: %d to import, %d to reconfigure, %d to remove.\n",
+		counts[FleetActionImport], counts[FleetActionReconfigure], counts[FleetActionRemove])
+	return nil
+}
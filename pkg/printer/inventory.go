@@ -0,0 +1,129 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Community License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Community-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// ClusterInventory is the inventory of a single cluster.
+type ClusterInventory struct {
+	Cluster           string         `json:"cluster"`
+	KubernetesVersion string         `json:"kubernetesVersion,omitempty"`
+	Nodes             int            `json:"nodes"`
+	Roles             map[string]int `json:"roles,omitempty"`
+	KubeletVersions   []string       `json:"kubeletVersions,omitempty"`
+	Platforms         []string       `json:"platforms,omitempty"`
+	AllocatableCPU    string         `json:"allocatableCPU,omitempty"`
+	AllocatableMemory string         `json:"allocatableMemory,omitempty"`
+	Namespaces        int            `json:"namespaces"`
+	Workloads         map[string]int `json:"workloads,omitempty"`
+	Warnings          []string       `json:"warnings,omitempty"`
+	Error             string         `json:"error,omitempty"`
+}
+
+// FleetInventory is the inventory of all the clusters along with the fleet-wide warnings.
+type FleetInventory struct {
+	Clusters []ClusterInventory `json:"clusters"`
+	Warnings []string           `json:"warnings,omitempty"`
+}
+
+// PrintInventory prints the fleet inventory. With the table format, the warnings of the clusters
+// and of the fleet are printed after the table.
+func PrintInventory(inventory *FleetInventory) error {
+	table := &Table{
+		Columns: []Column{
+			{Name: "CLUSTER"},
+			{Name: "VERSION"},
+			{Name: "NODES"},
+			{Name: "ROLES", Wide: true},
+			{Name: "KUBELET"},
+			{Name: "OS/ARCH", Wide: true},
+			{Name: "CPU"},
+			{Name: "MEMORY"},
+			{Name: "NAMESPACES"},
+			{Name: "WORKLOADS"},
+			{Name: "ERROR"},
+		},
+	}
+	for _, inv := range inventory.Clusters {
+		row := Row{Name: inv.Cluster, Object: inv}
+		if inv.Error != "" {
+			row.Cells = []string{inv.Cluster, "-", "-", "-", "-", "-", "-", "-", "-", "-", inv.Error}
+		} else {
+			workloads := 0
+			for _, n := range inv.Workloads {
+				workloads += n
+			}
+			row.Cells = []string{
+				inv.Cluster,
+				inv.KubernetesVersion,
+				strconv.Itoa(inv.Nodes),
+				formatCounts(inv.Roles),
+				strings.Join(inv.KubeletVersions, ","),
+				strings.Join(inv.Platforms, ","),
+				inv.AllocatableCPU,
+				inv.AllocatableMemory,
+				strconv.Itoa(inv.Namespaces),
+				strconv.Itoa(workloads),
+				"",
+			}
+		}
+		table.Rows = append(table.Rows, row)
+	}
+	if err := PrintObject(inventory, table); err != nil {
+		return err
+	}
+
+	if !IsTableOutput() {
+		return nil
+	}
+	var warnings []string
+	for _, inv := range inventory.Clusters {
+		for _, w := range inv.Warnings {
+			warnings = append(warnings, fmt.Sprintf("%s: %s", inv.Cluster, w))
+		}
+	}
+	warnings = append(warnings, inventory.Warnings...)
+	if len(warnings) > 0 {
+		_, _ = fmt.Fprintln(os.Stderr)
+	}
+	for _, w := range warnings {
+		_, _ = color.New(color.FgYellow).Fprintf(os.Stderr, "WARNING: %s\n", w)
+	}
+	return nil
+}
+
+// formatCounts formats the counts as name=count pairs sorted by name (e.g. control-plane=3,worker=5).
+func formatCounts(counts map[string]int) string {
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%d", k, counts[k]))
+	}
+	return strings.Join(pairs, ",")
+}
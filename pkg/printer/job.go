@@ -0,0 +1,110 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Community License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Community-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"go.bytebuilders.dev/cli/pkg/journal"
+)
+
+// JobResult is the outcome of a job that was run against a cluster.
+type JobResult struct {
+	Cluster string
+	Err     error
+}
+
+func PrintJobResults(results []JobResult) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 5, ' ', 0)
+	_, _ = fmt.Fprintln(w, "CLUSTER\tRESULT\tMESSAGE")
+	for _, r := range results {
+		if r.Err != nil {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", r.Cluster, strings.ToUpper(stepFailed), r.Err)
+		} else {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", r.Cluster, strings.ToUpper(stepSucceeded), "")
+		}
+	}
+	return w.Flush()
+}
+
+func PrintJobs(jobs []journal.Job) error {
+	table := &Table{
+		Columns: []Column{{Name: "ID"}, {Name: "OPERATION"}, {Name: "CLUSTER"}, {Name: "CONTEXT"}, {Name: "STATUS"}, {Name: "STARTED"}, {Name: "DURATION"}, {Name: "ERROR", Wide: true}},
+	}
+	for i := range jobs {
+		table.Rows = append(table.Rows, Row{
+			Cells: []string{jobs[i].ID, jobs[i].Operation, jobs[i].Cluster, jobs[i].Context, jobs[i].Status,
+				jobs[i].StartedAt.Local().Format(time.DateTime), jobDuration(&jobs[i]), jobs[i].Error},
+			Name:   jobs[i].ID,
+			Object: jobs[i],
+		})
+	}
+	return PrintObject(jobs, table)
+}
+
+func PrintJob(job *journal.Job) error {
+	if OutputFormat != "" && OutputFormat != "table" {
+		table := &Table{
+			Columns: []Column{{Name: "TIME"}, {Name: "STEP"}, {Name: "STATUS"}, {Name: "MESSAGE"}},
+		}
+		for _, s := range job.Steps {
+			table.Rows = append(table.Rows, Row{
+				Cells:  []string{s.Time.Local().Format(time.TimeOnly), s.Step, s.Status, s.Message},
+				Name:   s.ID,
+				Object: s,
+			})
+		}
+		return PrintObject(job, table)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(w, "ID:\t%s\n", job.ID)
+	_, _ = fmt.Fprintf(w, "Operation:\t%s\n", job.Operation)
+	_, _ = fmt.Fprintf(w, "Cluster:\t%s\n", job.Cluster)
+	_, _ = fmt.Fprintf(w, "Context:\t%s\n", job.Context)
+	_, _ = fmt.Fprintf(w, "Status:\t%s\n", job.Status)
+	_, _ = fmt.Fprintf(w, "Started:\t%s\n", job.StartedAt.Local().Format(time.DateTime))
+	_, _ = fmt.Fprintf(w, "Duration:\t%s\n", jobDuration(job))
+	if job.Error != "" {
+		_, _ = fmt.Fprintf(w, "Error:\t%s\n", job.Error)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if len(job.Steps) == 0 {
+		return nil
+	}
+
+	fmt.Println()
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 5, ' ', 0)
+	_, _ = fmt.Fprintln(w, "TIME\tSTEP\tSTATUS\tMESSAGE")
+	for _, s := range job.Steps {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", s.Time.Local().Format(time.TimeOnly), s.Step, s.Status, s.Message)
+	}
+	return w.Flush()
+}
+
+func jobDuration(job *journal.Job) string {
+	if job.FinishedAt == nil {
+		return "-"
+	}
+	return job.FinishedAt.Sub(job.StartedAt).Round(time.Second).String()
+}
@@ -0,0 +1,126 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Community License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Community-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// Types of the events printed for a job with -o json.
+const (
+	JobEventStarted = "started"
+	JobEventStep    = "step"
+	JobEventResult  = "result"
+)
+
+// JobEvent is printed as a single line of JSON for every step transition of a job with -o json.
+// The final event of a job has the type "result" and holds the status of the job.
+type JobEvent struct {
+	Type      string    `json:"type"`
+	JobID     string    `json:"jobID"`
+	Cluster   string    `json:"cluster,omitempty"`
+	StepID    string    `json:"stepID,omitempty"`
+	Step      string    `json:"step,omitempty"`
+	Status    string    `json:"status,omitempty"`
+	Message   string    `json:"message,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+	// Duration is the duration of the step or the job in seconds, once completed
+	Duration *float64 `json:"duration,omitempty"`
+}
+
+// eventMu serializes the events of jobs running in parallel.
+var eventMu sync.Mutex
+
+// PrintJobEvent prints the event as a single line of JSON.
+func PrintJobEvent(e JobEvent) error {
+	if e.Timestamp.IsZero() {
+		e.Timestamp = time.Now()
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	eventMu.Lock()
+	defer eventMu.Unlock()
+	_, err = os.Stdout.Write(append(data, '\n'))
+	return err
+}
+
+// Progressf prints a progress message meant for humans. With -o json it is written to stderr
+// instead, so that stdout only holds the job events.
+func Progressf(format string, a ...any) {
+	var w io.Writer = os.Stdout
+	if OutputFormat == "json" {
+		w = os.Stderr
+	}
+	_, _ = fmt.Fprintf(w, format, a...)
+}
+
+// jsonRenderer prints the job progress as JobEvents.
+type jsonRenderer struct {
+	jobID   string
+	cluster string
+}
+
+func (r *jsonRenderer) stepChanged(_ *stepTracker, s *jobStep) {
+	e := JobEvent{
+		Type:      JobEventStep,
+		JobID:     r.jobID,
+		Cluster:   r.cluster,
+		StepID:    s.id,
+		Step:      s.name,
+		Status:    s.status,
+		Message:   s.message,
+		Timestamp: time.Now(),
+	}
+	if s.completed() {
+		e.Timestamp = s.finishedAt
+		e.Duration = seconds(s.finishedAt.Sub(s.startedAt))
+	}
+	_ = PrintJobEvent(e)
+}
+
+func (r *jsonRenderer) refresh(*stepTracker) {}
+
+func (r *jsonRenderer) finish(t *stepTracker, failure *JobFailedError) error {
+	e := JobEvent{
+		Type:    JobEventResult,
+		JobID:   r.jobID,
+		Cluster: r.cluster,
+		Status:  stepSucceeded,
+	}
+	if failure != nil {
+		e.Status = failure.Status
+		e.Step = failure.Step
+		e.Message = failure.Message
+	}
+	if len(t.steps) > 0 {
+		e.Duration = seconds(time.Since(t.steps[0].startedAt))
+	}
+	return PrintJobEvent(e)
+}
+
+func seconds(d time.Duration) *float64 {
+	s := d.Seconds()
+	return &s
+}
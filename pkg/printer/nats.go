@@ -18,19 +18,45 @@ package printer
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
 	"sync"
+	"time"
+
+	"go.bytebuilders.dev/cli/pkg/exitcode"
 
 	"github.com/fatih/color"
+	"github.com/mattn/go-isatty"
 	"github.com/nats-io/nats.go"
+	"k8s.io/klog/v2"
 )
 
 type natsMessage struct {
-	ID     string `json:"id"`
-	Step   string `json:"step,omitempty"`
-	Status string `json:"status"`
+	ID string `json:"id"`
+	// Parent is the ID of the step this step is nested under. Steps without a known parent are
+	// nested under the first step of the job.
+	Parent  string `json:"parent,omitempty"`
+	Step    string `json:"step,omitempty"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// JobFailedError is returned when the parent step of a job reports that it has failed.
+// Step and Message describe the step that caused the failure.
+type JobFailedError struct {
+	ResponseID string
+	Status     string
+	Step       string
+	Message    string
+}
+
+func (e *JobFailedError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("job %s %s at step %q", e.ResponseID, strings.ToLower(e.Status), e.Step)
+	}
+	return fmt.Sprintf("job %s %s at step %q: %s", e.ResponseID, strings.ToLower(e.Status), e.Step, e.Message)
 }
 
 const (
@@ -39,59 +65,255 @@ const (
 	stepFailed    = "Failed"
 )
 
+// ErrJobInactive is returned when no message has been received for a job within the inactivity timeout.
+var ErrJobInactive = errors.New("no progress reported by the job")
+
 func PrintNATSJobSteps(wg *sync.WaitGroup, nc *nats.Conn, responseID string, done <-chan os.Signal) error {
 	defer wg.Done()
+	return WatchNATSJobSteps(nc, responseID, JobStepsOptions{}, done)
+}
 
-	subject := fmt.Sprintf("natjobs.resp.%s", responseID)
-	steps := make(map[string]string)
-	parentID := ""
+// JobStepsOptions configures how the steps of a job are watched and printed.
+type JobStepsOptions struct {
+	// Prefix is prepended to every printed line. It is used to interleave the steps of
+	// multiple jobs running in parallel.
+	Prefix string
+	// Replay replays the steps that have already happened if the job messages are retained
+	// in a JetStream stream.
+	Replay bool
+	// Cluster is the name of the cluster the job runs against. It is included in the JSON events.
+	Cluster string
+	// OnMessage is called with every raw message received for the job.
+	OnMessage func(data []byte)
+	// InactivityTimeout stops watching the job with ErrJobInactive if no message is received
+	// within the duration. Zero disables the timeout.
+	InactivityTimeout time.Duration
+	// Reconnect returns a new connection once the current one has been closed. The steps missed
+	// in the meantime are replayed if the job messages are retained. If Reconnect is nil, watching
+	// fails once the connection is closed.
+	Reconnect func() (*nats.Conn, error)
+}
 
-	msgStream := make(chan *nats.Msg, 100)
-	sub, err := nc.ChanSubscribe(subject, msgStream)
+// WatchNATSJobSteps subscribes to the steps of a job and prints them until the job has finished.
+// Use SubscribeNATSJobSteps instead if the job hasn't been started yet.
+func WatchNATSJobSteps(nc *nats.Conn, responseID string, opts JobStepsOptions, done <-chan os.Signal) error {
+	w, err := SubscribeNATSJobSteps(nc, responseID, opts)
+	if err != nil {
+		return err
+	}
+	return w.Watch(done)
+}
+
+// JobStepsWatcher prints the steps of a job. The messages received after the subscription
+// has been established are queued until Watch is called.
+type JobStepsWatcher struct {
+	nc         *nats.Conn
+	responseID string
+	opts       JobStepsOptions
+
+	sub     *nats.Subscription
+	msgs    chan *nats.Msg
+	stopped chan struct{}
+	once    sync.Once
+}
+
+// SubscribeNATSJobSteps subscribes to the steps of a job and makes sure that the server has
+// registered the subscription, so that no step is missed if the job is started afterwards.
+func SubscribeNATSJobSteps(nc *nats.Conn, responseID string, opts JobStepsOptions) (*JobStepsWatcher, error) {
+	w := &JobStepsWatcher{
+		nc:         nc,
+		responseID: responseID,
+		opts:       opts,
+		msgs:       make(chan *nats.Msg, 100),
+		stopped:    make(chan struct{}),
+	}
+	if err := w.subscribe(opts.Replay); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *JobStepsWatcher) subscribe(replay bool) error {
+	subject := fmt.Sprintf("natjobs.resp.%s", w.responseID)
+	// the handler blocks while the channel is full, leaving the messages pending in the
+	// subscription, whose limits are removed so that no step is dropped for a slow consumer
+	sub, err := subscribeJobSteps(w.nc, subject, func(msg *nats.Msg) {
+		select {
+		case w.msgs <- msg:
+		case <-w.stopped:
+		}
+	}, replay)
 	if err != nil {
 		return fmt.Errorf("failed to subscribe. Reason: %w", err)
 	}
+	if err := sub.SetPendingLimits(-1, -1); err != nil {
+		klog.V(3).Infof("failed to remove the pending limits of the subscription. Reason: %v", err)
+	}
+	if err := w.nc.Flush(); err != nil {
+		_ = sub.Unsubscribe()
+		return fmt.Errorf("failed to flush the subscription. Reason: %w", err)
+	}
+	w.sub = sub
+	return nil
+}
 
-	stopListening := func(err error) error {
-		unsubErr := sub.Unsubscribe()
-		if unsubErr != nil {
-			return fmt.Errorf("failed to unsbuscribe. Reason: %w", unsubErr)
+// Close unsubscribes from the job. It is called by Watch, so it is only needed if Watch is never called.
+func (w *JobStepsWatcher) Close() error {
+	var err error
+	w.once.Do(func() {
+		close(w.stopped)
+		if dropped, _ := w.sub.Dropped(); dropped > 0 {
+			klog.Warningf("%d messages of job %s have been dropped", dropped, w.responseID)
 		}
-		close(msgStream)
-		return err
+		if w.nc.IsClosed() {
+			return
+		}
+		if unsubErr := w.sub.Unsubscribe(); unsubErr != nil {
+			err = fmt.Errorf("failed to unsubscribe. Reason: %w", unsubErr)
+		}
+	})
+	return err
+}
+
+// reconnect replaces the closed connection and subscribes again, replaying the missed steps if possible.
+func (w *JobStepsWatcher) reconnect() error {
+	if w.opts.Reconnect == nil {
+		return fmt.Errorf("connection to NATS has been closed")
+	}
+	var err error
+	for attempt := 1; attempt <= 5; attempt++ {
+		var nc *nats.Conn
+		nc, err = w.opts.Reconnect()
+		if err == nil {
+			w.nc = nc
+			if err = w.subscribe(true); err == nil {
+				return nil
+			}
+		}
+		klog.Warningf("failed to reconnect to NATS (attempt %d). Reason: %v", attempt, err)
+		time.Sleep(time.Duration(attempt) * time.Second)
+	}
+	return fmt.Errorf("failed to reconnect to NATS. Reason: %w", err)
+}
+
+// Watch prints the steps of the job until it has finished. A *JobFailedError is returned if the
+// job has failed. With -o json the steps are printed as JobEvents.
+func (w *JobStepsWatcher) Watch(done <-chan os.Signal) (err error) {
+	defer func() {
+		if closeErr := w.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	prefix := w.opts.Prefix
+	parentID := ""
+	var failure *JobFailedError
+	// messages can be delivered again after reconnecting
+	seen := map[string]bool{}
+
+	tracker := newStepTracker()
+	var renderer stepRenderer = &lineRenderer{prefix: prefix}
+	var tick <-chan time.Time
+	switch {
+	case OutputFormat == "json":
+		renderer = &jsonRenderer{jobID: w.responseID, cluster: w.opts.Cluster}
+	case prefix == "" && !color.NoColor && isatty.IsTerminal(os.Stdout.Fd()):
+		renderer = &treeRenderer{}
+		ticker := time.NewTicker(100 * time.Millisecond)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	var inactive <-chan time.Time
+	var timer *time.Timer
+	if w.opts.InactivityTimeout > 0 {
+		timer = time.NewTimer(w.opts.InactivityTimeout)
+		defer timer.Stop()
+		inactive = timer.C
+	}
+
+	closed := w.nc.StatusChanged(nats.CLOSED)
+	defer func() { w.nc.RemoveStatusListener(closed) }()
+	if w.nc.IsClosed() {
+		closed <- nats.CLOSED
 	}
+
 	for {
 		select {
 		case <-done:
-			return stopListening(fmt.Errorf("command terminated by user"))
-		case msg := <-msgStream:
+			return exitcode.ErrCancelled
+		case <-tick:
+			renderer.refresh(tracker)
+		case <-inactive:
+			return fmt.Errorf("%w: no message received for job %s in %s", ErrJobInactive, w.responseID, w.opts.InactivityTimeout)
+		case <-closed:
+			w.nc.RemoveStatusListener(closed)
+			if err := w.reconnect(); err != nil {
+				return err
+			}
+			closed = w.nc.StatusChanged(nats.CLOSED)
+		case msg := <-w.msgs:
+			if seen[string(msg.Data)] {
+				continue
+			}
+			seen[string(msg.Data)] = true
+			if timer != nil {
+				timer.Reset(w.opts.InactivityTimeout)
+			}
+			if w.opts.OnMessage != nil {
+				w.opts.OnMessage(msg.Data)
+			}
 			resp := natsMessage{}
 			err := json.Unmarshal(msg.Data, &resp)
 			if err != nil {
-				return stopListening(fmt.Errorf("failed to parse message. Reason: %w", err))
+				return fmt.Errorf("failed to parse message. Reason: %w", err)
 			}
-			if resp.Step != "" {
-				if parentID == "" {
-					parentID = resp.ID
-				}
-				steps[resp.ID] = resp.Step
+			if resp.Step != "" && parentID == "" {
+				parentID = resp.ID
 			}
-			if isStepStartedOrCompleted(resp.Status) {
-				switch resp.Status {
-				case stepSucceeded:
-					color.Green(fmt.Sprintf("%s %s", strings.ToUpper(resp.Status), steps[resp.ID]))
-				case stepFailed:
-					color.Red(fmt.Sprintf("%s %s", strings.ToUpper(resp.Status), steps[resp.ID]))
-				default:
-					color.Blue(fmt.Sprintf("%s %s", strings.ToUpper(resp.Status), steps[resp.ID]))
-
+			step := tracker.update(resp, time.Now())
+			if !isStepStartedOrCompleted(resp.Status) {
+				continue
+			}
+			renderer.stepChanged(tracker, step)
+			// the first failed step is the one that caused the job to fail
+			if resp.Status == stepFailed && failure == nil {
+				failure = &JobFailedError{
+					ResponseID: w.responseID,
+					Status:     resp.Status,
+					Step:       step.name,
+					Message:    step.message,
 				}
-				if resp.ID == parentID && (resp.Status == "Success" || resp.Status == "Failed") {
-					return stopListening(nil)
+			}
+			if resp.ID == parentID && resp.Status == stepSucceeded {
+				return renderer.finish(tracker, nil)
+			}
+			if resp.ID == parentID && resp.Status == stepFailed {
+				if err := renderer.finish(tracker, failure); err != nil {
+					return err
 				}
+				return failure
+			}
+		}
+	}
+}
+
+// subscribeJobSteps subscribes to the job subject. When replay is requested, it tries to consume
+// the subject from the beginning of a JetStream stream and falls back to a core NATS subscription
+// that only receives new messages.
+func subscribeJobSteps(nc *nats.Conn, subject string, handler nats.MsgHandler, replay bool) (*nats.Subscription, error) {
+	if replay {
+		js, err := nc.JetStream()
+		if err == nil {
+			var sub *nats.Subscription
+			sub, err = js.Subscribe(subject, handler, nats.OrderedConsumer(), nats.DeliverAll())
+			if err == nil {
+				return sub, nil
 			}
 		}
+		klog.V(3).Infof("past steps of the job can not be replayed. Reason: %v", err)
 	}
+	return nc.Subscribe(subject, handler)
 }
 
 func isStepStartedOrCompleted(status string) bool {
@@ -0,0 +1,398 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Community License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Community-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/fatih/color"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/client-go/util/jsonpath"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	// OutputFormat is the format requested with -o
+	OutputFormat string
+	// NoHeaders omits the headers of the tables
+	NoHeaders bool
+	// Columns selects the columns of the tables by name. It is set by the commands that support --columns.
+	Columns []string
+)
+
+const (
+	jsonPathPrefix      = "jsonpath="
+	goTemplatePrefix    = "go-template="
+	customColumnsPrefix = "custom-columns="
+
+	// defaultForeground is the ANSI code of the default foreground color
+	defaultForeground color.Attribute = 39
+)
+
+// OutputFormats are the output formats that can be completed in the shell.
+var OutputFormats = []string{"table", "wide", "json", "ndjson", "yaml", "csv", "markdown", "name", "jsonpath=", "go-template=", "custom-columns="}
+
+// OutputFormatUsage describes the supported output formats.
+const OutputFormatUsage = "Output format. One of: (table, wide, json, ndjson, yaml, csv, markdown, name, jsonpath=..., go-template=..., custom-columns=NAME:.path,...). Default is table."
+
+// Column is a column of a table.
+type Column struct {
+	Name string
+	// Wide columns are only shown with -o wide
+	Wide bool
+	// Color returns the color of a cell, or color.Reset to keep the default color. It is not used
+	// if the color output is disabled.
+	Color func(cell string) color.Attribute
+}
+
+// Row is a row of a table.
+type Row struct {
+	Cells []string
+	// Name identifies the object of the row with -o name
+	Name string
+	// Object is the object the row was generated from. The custom columns are evaluated against it.
+	Object any
+}
+
+// Table is the tabular representation of the output of a command.
+type Table struct {
+	Columns []Column
+	Rows    []Row
+}
+
+// PrintObject prints obj in the format requested with -o. obj is printed as is with json and yaml,
+// and is the input of jsonpath and go-template. The table is used for the table, wide, name and
+// custom-columns formats. If table is nil, obj is printed as yaml by default.
+func PrintObject(obj any, table *Table) error {
+	format := OutputFormat
+	if format == "" && table == nil {
+		format = "yaml"
+	}
+
+	switch {
+	case format == "json":
+		data, err := json.MarshalIndent(obj, "", " ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	case format == "ndjson":
+		return printNDJSON(obj)
+	case format == "yaml":
+		data, err := yaml.Marshal(obj)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	case strings.HasPrefix(format, jsonPathPrefix):
+		p, err := printers.NewJSONPathPrinter(strings.TrimPrefix(format, jsonPathPrefix))
+		if err != nil {
+			return fmt.Errorf("invalid jsonpath template. Reason: %w", err)
+		}
+		return printGeneric(p, obj)
+	case strings.HasPrefix(format, goTemplatePrefix):
+		p, err := printers.NewGoTemplatePrinter([]byte(strings.TrimPrefix(format, goTemplatePrefix)))
+		if err != nil {
+			return fmt.Errorf("invalid go-template. Reason: %w", err)
+		}
+		return printGeneric(p, obj)
+	}
+
+	if table == nil {
+		return fmt.Errorf("output format %q is not supported by this command", format)
+	}
+	switch {
+	case format == "" || format == "table" || format == "wide" || format == "csv" || format == "markdown":
+		// csv and markdown are meant for exports, so they always include the wide columns
+		wide := format != "" && format != "table"
+		if len(Columns) > 0 {
+			t, err := selectColumns(table, Columns)
+			if err != nil {
+				return err
+			}
+			table, wide = t, true
+		}
+		switch format {
+		case "csv":
+			return printCSV(os.Stdout, table)
+		case "markdown":
+			return printMarkdown(os.Stdout, table)
+		}
+		return printTable(os.Stdout, table, wide)
+	case format == "name":
+		for _, row := range table.Rows {
+			fmt.Println(row.Name)
+		}
+		return nil
+	case strings.HasPrefix(format, customColumnsPrefix):
+		t, err := customColumnsTable(strings.TrimPrefix(format, customColumnsPrefix), table)
+		if err != nil {
+			return err
+		}
+		return printTable(os.Stdout, t, false)
+	}
+	return fmt.Errorf("unknown output format %q", format)
+}
+
+// IsTableOutput returns true if the output is a human-readable table rather than a document
+// that is meant to be parsed.
+func IsTableOutput() bool {
+	return OutputFormat == "" || OutputFormat == "table" || OutputFormat == "wide"
+}
+
+// printNDJSON prints every item of obj as a compact JSON object on its own line. obj can be a slice
+// or a list object with an Items field. Any other object is printed as a single line.
+func printNDJSON(obj any) error {
+	v := reflect.Indirect(reflect.ValueOf(obj))
+	if v.Kind() == reflect.Struct {
+		if items := v.FieldByName("Items"); items.IsValid() && items.Kind() == reflect.Slice {
+			v = items
+		}
+	}
+	if v.Kind() != reflect.Slice {
+		v = reflect.ValueOf([]any{obj})
+	}
+	for i := 0; i < v.Len(); i++ {
+		data, err := json.Marshal(v.Index(i).Interface())
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+	}
+	return nil
+}
+
+func printTable(out io.Writer, table *Table, wide bool) error {
+	w := tabwriter.NewWriter(out, 0, 0, 5, ' ', tabwriter.StripEscape)
+	if !NoHeaders {
+		var headers []string
+		for _, c := range table.Columns {
+			if wide || !c.Wide {
+				headers = append(headers, colorize(c.Name, c, true))
+			}
+		}
+		_, _ = fmt.Fprintln(w, strings.Join(headers, "\t"))
+	}
+	for _, row := range table.Rows {
+		var cells []string
+		for i, c := range table.Columns {
+			if wide || !c.Wide {
+				cells = append(cells, colorize(row.Cells[i], c, false))
+			}
+		}
+		_, _ = fmt.Fprintln(w, strings.Join(cells, "\t"))
+	}
+	return w.Flush()
+}
+
+// printCSV prints all the columns of the table as RFC 4180 comma-separated values.
+func printCSV(out io.Writer, table *Table) error {
+	w := csv.NewWriter(out)
+	if !NoHeaders {
+		headers := make([]string, 0, len(table.Columns))
+		for _, c := range table.Columns {
+			headers = append(headers, c.Name)
+		}
+		if err := w.Write(headers); err != nil {
+			return err
+		}
+	}
+	for _, row := range table.Rows {
+		if err := w.Write(row.Cells); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// printMarkdown prints all the columns of the table as a GitHub flavored markdown table. The headers
+// are always printed, as a markdown table cannot be rendered without them.
+func printMarkdown(out io.Writer, table *Table) error {
+	escape := strings.NewReplacer("|", "\\|", "<", "&lt;", ">", "&gt;", "\n", "<br>", "\r", "")
+	headers := make([]string, 0, len(table.Columns))
+	separators := make([]string, 0, len(table.Columns))
+	for _, c := range table.Columns {
+		headers = append(headers, c.Name)
+		separators = append(separators, "---")
+	}
+	if _, err := fmt.Fprintf(out, "| %s |\n| %s |\n", strings.Join(headers, " | "), strings.Join(separators, " | ")); err != nil {
+		return err
+	}
+	for _, row := range table.Rows {
+		cells := make([]string, 0, len(row.Cells))
+		for _, cell := range row.Cells {
+			cells = append(cells, escape.Replace(cell))
+		}
+		if _, err := fmt.Fprintf(out, "| %s |\n", strings.Join(cells, " | ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// colorize wraps the cell in ANSI color codes. The tabwriter counts the codes as part of the cell
+// width, so every cell of a colored column, including the header, is wrapped in codes of the same
+// length to keep the column aligned. Cells without a color use the default foreground color.
+func colorize(cell string, c Column, header bool) string {
+	if c.Color == nil || color.NoColor {
+		return cell
+	}
+	attr := color.Reset
+	if !header {
+		attr = c.Color(cell)
+	}
+	if attr == color.Reset {
+		attr = defaultForeground
+	}
+	return fmt.Sprintf("\x1b[%dm%s\x1b[0m", attr, cell)
+}
+
+// selectColumns returns a table with only the given columns in the given order. The names are
+// matched case-insensitively and '-' can be used instead of '_'.
+func selectColumns(table *Table, names []string) (*Table, error) {
+	indices := make([]int, 0, len(names))
+	for _, name := range names {
+		key := strings.ReplaceAll(strings.ToUpper(strings.TrimSpace(name)), "-", "_")
+		idx := -1
+		for i, c := range table.Columns {
+			if c.Name == key {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			valid := make([]string, 0, len(table.Columns))
+			for _, c := range table.Columns {
+				valid = append(valid, strings.ToLower(c.Name))
+			}
+			return nil, fmt.Errorf("unknown column %q. Valid values are: %s", name, strings.Join(valid, ", "))
+		}
+		indices = append(indices, idx)
+	}
+
+	result := &Table{}
+	for _, idx := range indices {
+		c := table.Columns[idx]
+		c.Wide = false
+		result.Columns = append(result.Columns, c)
+	}
+	for _, row := range table.Rows {
+		cells := make([]string, 0, len(indices))
+		for _, idx := range indices {
+			cells = append(cells, row.Cells[idx])
+		}
+		result.Rows = append(result.Rows, Row{Cells: cells, Name: row.Name, Object: row.Object})
+	}
+	return result, nil
+}
+
+// printGeneric prints obj with one of the cli-runtime printers. As they expect a Kubernetes object,
+// obj is converted to an unstructured object. Lists are wrapped in a List object, so that their
+// items are accessible as .items like with kubectl.
+func printGeneric(p printers.ResourcePrinter, obj any) error {
+	content, err := toGeneric(obj)
+	if err != nil {
+		return err
+	}
+	var m map[string]any
+	switch v := content.(type) {
+	case map[string]any:
+		m = v
+	case []any:
+		m = map[string]any{
+			"apiVersion": "v1",
+			"kind":       "List",
+			"items":      v,
+		}
+	default:
+		return fmt.Errorf("unsupported object of type %T", obj)
+	}
+	return p.PrintObj(&unstructured.Unstructured{Object: m}, os.Stdout)
+}
+
+func toGeneric(obj any) (any, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var content any
+	if err := json.Unmarshal(data, &content); err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+// customColumnsTable returns a table with the columns described by spec (e.g. NAME:.spec.name,PHASE:.status.phase),
+// evaluated against the objects of the rows.
+func customColumnsTable(spec string, table *Table) (*Table, error) {
+	var columns []Column
+	var parsers []*jsonpath.JSONPath
+	for _, col := range strings.Split(spec, ",") {
+		name, path, found := strings.Cut(col, ":")
+		if !found || name == "" || path == "" {
+			return nil, fmt.Errorf("invalid custom column %q. Expected format is NAME:.json.path", col)
+		}
+		if !strings.HasPrefix(path, "{") {
+			path = "{" + path + "}"
+		}
+		parser := jsonpath.New(name).AllowMissingKeys(true)
+		if err := parser.Parse(path); err != nil {
+			return nil, fmt.Errorf("invalid path of custom column %s. Reason: %w", name, err)
+		}
+		columns = append(columns, Column{Name: name})
+		parsers = append(parsers, parser)
+	}
+
+	result := &Table{Columns: columns}
+	for _, row := range table.Rows {
+		content, err := toGeneric(row.Object)
+		if err != nil {
+			return nil, err
+		}
+		cells := make([]string, 0, len(parsers))
+		for _, parser := range parsers {
+			values, err := parser.FindResults(content)
+			if err != nil {
+				return nil, err
+			}
+			var texts []string
+			for _, value := range values {
+				for _, v := range value {
+					texts = append(texts, fmt.Sprint(v.Interface()))
+				}
+			}
+			cell := strings.Join(texts, ",")
+			if cell == "" {
+				cell = "<none>"
+			}
+			cells = append(cells, cell)
+		}
+		result.Rows = append(result.Rows, Row{Cells: cells, Name: row.Name, Object: row.Object})
+	}
+	return result, nil
+}
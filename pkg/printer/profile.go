@@ -0,0 +1,43 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Community License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Community-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"sort"
+	"strings"
+
+	uiapi "kmodules.xyz/resource-metadata/apis/ui/v1alpha1"
+)
+
+func PrintClusterProfiles(profiles []uiapi.ClusterProfile) error {
+	table := &Table{
+		Columns: []Column{{Name: "NAME"}, {Name: "TITLE"}, {Name: "PROVIDER"}, {Name: "FEATURE_SETS"}},
+	}
+	for i := range profiles {
+		featureSets := make([]string, 0, len(profiles[i].Spec.RequiredFeatureSets))
+		for name := range profiles[i].Spec.RequiredFeatureSets {
+			featureSets = append(featureSets, name)
+		}
+		sort.Strings(featureSets)
+		table.Rows = append(table.Rows, Row{
+			Cells:  []string{profiles[i].Name, profiles[i].Spec.Title, profiles[i].Spec.Provider, strings.Join(featureSets, ",")},
+			Name:   profiles[i].Name,
+			Object: profiles[i],
+		})
+	}
+	return PrintObject(profiles, table)
+}
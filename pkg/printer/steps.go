@@ -0,0 +1,234 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Community License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Community-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/fatih/color"
+	"golang.org/x/term"
+)
+
+// defaultTreeLines is the number of lines of the step tree redrawn when the terminal size is unknown.
+const defaultTreeLines = 20
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// jobStep is a step of a job. The steps are nested under the step referenced by the parent ID
+// of their messages.
+type jobStep struct {
+	id         string
+	children   []*jobStep
+	name       string
+	status     string
+	message    string
+	depth      int
+	startedAt  time.Time
+	finishedAt time.Time
+}
+
+func (s *jobStep) completed() bool {
+	return s.status == stepSucceeded || s.status == stepFailed
+}
+
+func (s *jobStep) duration(now time.Time) time.Duration {
+	if s.completed() {
+		return s.finishedAt.Sub(s.startedAt).Round(time.Second)
+	}
+	return now.Sub(s.startedAt).Round(time.Second)
+}
+
+// stepTracker builds the step tree of a job from its messages. The steps are kept in the order
+// they have started and the first step is the root of the tree.
+type stepTracker struct {
+	steps []*jobStep
+	byID  map[string]*jobStep
+}
+
+func newStepTracker() *stepTracker {
+	return &stepTracker{byID: map[string]*jobStep{}}
+}
+
+func (t *stepTracker) update(msg natsMessage, now time.Time) *jobStep {
+	s, ok := t.byID[msg.ID]
+	if !ok {
+		s = &jobStep{
+			id:        msg.ID,
+			startedAt: now,
+		}
+		parent := t.byID[msg.Parent]
+		if parent == nil && len(t.steps) > 0 {
+			parent = t.steps[0]
+		}
+		if parent != nil {
+			s.depth = parent.depth + 1
+			parent.children = append(parent.children, s)
+		}
+		t.byID[msg.ID] = s
+		t.steps = append(t.steps, s)
+	}
+	if msg.Step != "" {
+		s.name = msg.Step
+	}
+	if msg.Message != "" {
+		s.message = msg.Message
+	}
+	if isStepStartedOrCompleted(msg.Status) {
+		s.status = msg.Status
+	}
+	if s.completed() && s.finishedAt.IsZero() {
+		s.finishedAt = now
+	}
+	return s
+}
+
+// tree returns the steps in the depth-first order of the tree.
+func (t *stepTracker) tree() []*jobStep {
+	steps := make([]*jobStep, 0, len(t.steps))
+	var walk func(s *jobStep)
+	walk = func(s *jobStep) {
+		steps = append(steps, s)
+		for _, child := range s.children {
+			walk(child)
+		}
+	}
+	if len(t.steps) > 0 {
+		walk(t.steps[0])
+	}
+	return steps
+}
+
+// stepRenderer renders the progress of a job.
+type stepRenderer interface {
+	// stepChanged is called whenever the status of a step has changed
+	stepChanged(t *stepTracker, s *jobStep)
+	// refresh is called periodically to animate the in-progress steps
+	refresh(t *stepTracker)
+	// finish is called once the job has completed. failure is nil if the job has succeeded.
+	finish(t *stepTracker, failure *JobFailedError) error
+}
+
+// lineRenderer prints a line for every status change. It is used when stdout isn't a terminal.
+type lineRenderer struct {
+	prefix string
+}
+
+func (r *lineRenderer) stepChanged(_ *stepTracker, s *jobStep) {
+	switch s.status {
+	case stepSucceeded:
+		color.Green("%s%s %s (%s)", r.prefix, strings.ToUpper(s.status), s.name, s.duration(time.Now()))
+	case stepFailed:
+		color.Red("%s%s %s (%s)", r.prefix, strings.ToUpper(s.status), s.name, s.duration(time.Now()))
+		if s.message != "" {
+			color.Red("%s  %s", r.prefix, s.message)
+		}
+	default:
+		color.Blue("%s%s %s", r.prefix, strings.ToUpper(s.status), s.name)
+	}
+}
+
+func (r *lineRenderer) refresh(*stepTracker) {}
+
+func (r *lineRenderer) finish(t *stepTracker, _ *JobFailedError) error {
+	// the steps of parallel jobs are summarized by the caller
+	if r.prefix != "" {
+		return nil
+	}
+	return printStepSummary(t)
+}
+
+// treeRenderer redraws the step tree in place with a spinner for the in-progress steps. Only the
+// lines that fit in the terminal are redrawn, the cursor can't be moved above the top of the screen.
+type treeRenderer struct {
+	lines int
+	frame int
+}
+
+// maxLines returns the number of lines of the tree that can be redrawn in place.
+func (r *treeRenderer) maxLines() int {
+	_, height, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || height < 3 {
+		return defaultTreeLines
+	}
+	// keep a line for the cursor below the tree
+	return height - 1
+}
+
+func (r *treeRenderer) stepChanged(t *stepTracker, _ *jobStep) {
+	r.refresh(t)
+}
+
+func (r *treeRenderer) refresh(t *stepTracker) {
+	now := time.Now()
+	r.frame = (r.frame + 1) % len(spinnerFrames)
+
+	maxLines := r.maxLines()
+	var sb strings.Builder
+	if r.lines > 0 {
+		// move the cursor back to the first line of the tree
+		_, _ = fmt.Fprintf(&sb, "\x1b[%dA", min(r.lines, maxLines))
+	}
+	r.lines = 0
+	steps := t.tree()
+	if len(steps) > maxLines {
+		// show the latest steps, the summary lists all of them once the job has finished
+		hidden := len(steps) - maxLines + 1
+		_, _ = fmt.Fprintf(&sb, "\x1b[2K%s\n", color.New(color.Faint).Sprintf("... %d more steps", hidden))
+		r.lines++
+		steps = steps[hidden:]
+	}
+	for _, s := range steps {
+		var icon string
+		switch s.status {
+		case stepSucceeded:
+			icon = color.GreenString("✓")
+		case stepFailed:
+			icon = color.RedString("✗")
+		default:
+			icon = color.BlueString(spinnerFrames[r.frame])
+		}
+		line := fmt.Sprintf("%s%s %s  %s  %s", strings.Repeat("  ", s.depth), icon, s.name,
+			color.New(color.Faint).Sprint(s.startedAt.Local().Format(time.TimeOnly)), s.duration(now))
+		if s.status == stepFailed && s.message != "" {
+			line += "  " + color.RedString(s.message)
+		}
+		_, _ = fmt.Fprintf(&sb, "\x1b[2K%s\n", line)
+		r.lines++
+	}
+	_, _ = fmt.Fprint(color.Output, sb.String())
+}
+
+func (r *treeRenderer) finish(t *stepTracker, _ *JobFailedError) error {
+	r.refresh(t)
+	return printStepSummary(t)
+}
+
+// printStepSummary prints a table with the status and duration of every step.
+func printStepSummary(t *stepTracker) error {
+	now := time.Now()
+	fmt.Println()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 5, ' ', 0)
+	_, _ = fmt.Fprintln(w, "STEP\tSTATUS\tSTARTED\tDURATION")
+	for _, s := range t.tree() {
+		_, _ = fmt.Fprintf(w, "%s%s\t%s\t%s\t%s\n", strings.Repeat("  ", s.depth), s.name, s.status, s.startedAt.Local().Format(time.TimeOnly), s.duration(now))
+	}
+	return w.Flush()
+}
@@ -0,0 +1,170 @@
+/*
+Copyright AppsCode Inc. and Contributors
+
+Licensed under the AppsCode Community License 1.0.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://github.com/appscode/licenses/raw/1.0.0/AppsCode-Community-1.0.0.md
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"go.bytebuilders.dev/resource-model/apis/cluster/v1alpha1"
+
+	"github.com/fatih/color"
+	"github.com/mattn/go-isatty"
+	rsapi "kmodules.xyz/resource-metadata/apis/meta/v1alpha1"
+)
+
+const (
+	ClusterEventAdded    = "ADDED"
+	ClusterEventModified = "MODIFIED"
+	ClusterEventDeleted  = "DELETED"
+
+	// tabwriter counts the color codes in the column width, so every cell is wrapped with
+	// codes of the same length whether it is highlighted or not
+	highlightColor = "\x1b[1;33m"
+	plainColor     = "\x1b[0;39m"
+	resetColor     = "\x1b[0m"
+	clearScreen    = "\x1b[H\x1b[2J"
+)
+
+type ClusterEvent struct {
+	Type          string             `json:"type"`
+	Time          time.Time          `json:"time"`
+	Name          string             `json:"name"`
+	DisplayName   string             `json:"displayName,omitempty"`
+	Provider      string             `json:"provider,omitempty"`
+	Phase         rsapi.ClusterPhase `json:"phase,omitempty"`
+	PreviousPhase rsapi.ClusterPhase `json:"previousPhase,omitempty"`
+}
+
+// ClusterWatcher prints the clusters on every poll and highlights the ones whose phase
+// changed since the previous poll. With json output, only the changes are printed as NDJSON events.
+type ClusterWatcher struct {
+	previous map[string]v1alpha1.ClusterInfo
+}
+
+func NewClusterWatcher() *ClusterWatcher {
+	return &ClusterWatcher{}
+}
+
+func (w *ClusterWatcher) Update(clusters []v1alpha1.ClusterInfo) error {
+	firstPoll := w.previous == nil
+	events := w.diff(clusters)
+	if OutputFormat == "json" || OutputFormat == "ndjson" {
+		for i := range events {
+			data, err := json.Marshal(events[i])
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(data))
+		}
+		return nil
+	}
+	if firstPoll {
+		// everything is new on the first poll, so there is nothing to highlight
+		events = nil
+	}
+	return w.printTable(clusters, events)
+}
+
+func (w *ClusterWatcher) diff(clusters []v1alpha1.ClusterInfo) []ClusterEvent {
+	now := time.Now().UTC()
+	current := make(map[string]v1alpha1.ClusterInfo, len(clusters))
+	var events []ClusterEvent
+	for _, cluster := range clusters {
+		current[cluster.Spec.Name] = cluster
+		event := ClusterEvent{
+			Time:        now,
+			Name:        cluster.Spec.Name,
+			DisplayName: cluster.Spec.DisplayName,
+			Provider:    string(cluster.Spec.Provider),
+			Phase:       cluster.Status.Phase,
+		}
+		prev, found := w.previous[cluster.Spec.Name]
+		switch {
+		case !found:
+			event.Type = ClusterEventAdded
+		case prev.Status.Phase != cluster.Status.Phase:
+			event.Type = ClusterEventModified
+			event.PreviousPhase = prev.Status.Phase
+		default:
+			continue
+		}
+		events = append(events, event)
+	}
+	for name, prev := range w.previous {
+		if _, found := current[name]; !found {
+			events = append(events, ClusterEvent{
+				Type:          ClusterEventDeleted,
+				Time:          now,
+				Name:          name,
+				DisplayName:   prev.Spec.DisplayName,
+				Provider:      string(prev.Spec.Provider),
+				PreviousPhase: prev.Status.Phase,
+			})
+		}
+	}
+	w.previous = current
+	return events
+}
+
+func (w *ClusterWatcher) printTable(clusters []v1alpha1.ClusterInfo, events []ClusterEvent) error {
+	changed := make(map[string]ClusterEvent, len(events))
+	for _, event := range events {
+		changed[event.Name] = event
+	}
+
+	if isatty.IsTerminal(os.Stdout.Fd()) {
+		fmt.Print(clearScreen)
+	}
+	fmt.Printf("Last updated: %s\n\n", time.Now().Format(time.RFC1123))
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 5, ' ', 0)
+	_, _ = fmt.Fprintln(tw, watchRow(false, "NAME", "DISPLAY_NAME", "PROVIDER", "PHASE"))
+	for _, cluster := range clusters {
+		phase := string(cluster.Status.Phase)
+		event, found := changed[cluster.Spec.Name]
+		if found && event.PreviousPhase != "" {
+			phase = fmt.Sprintf("%s (was %s)", phase, event.PreviousPhase)
+		}
+		_, _ = fmt.Fprintln(tw, watchRow(found, cluster.Spec.Name, cluster.Spec.DisplayName, string(cluster.Spec.Provider), phase))
+	}
+	for _, event := range events {
+		if event.Type == ClusterEventDeleted {
+			_, _ = fmt.Fprintln(tw, watchRow(true, event.Name, event.DisplayName, event.Provider, "<removed>"))
+		}
+	}
+	return tw.Flush()
+}
+
+// watchRow joins the cells of a row, coloring each cell separately so that the columns stay aligned.
+func watchRow(highlighted bool, cells ...string) string {
+	if color.NoColor {
+		return strings.Join(cells, "\t")
+	}
+	start := plainColor
+	if highlighted {
+		start = highlightColor
+	}
+	for i := range cells {
+		cells[i] = start + cells[i] + resetColor
+	}
+	return strings.Join(cells, "\t")
+}